@@ -0,0 +1,205 @@
+package signals
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Read the errors from a channel returned by SendAsync, until it is closed or the context is done.
+//
+// Returns the non-nil errors read from the channel. If the context is done
+// before the channel is closed, the errors read so far are returned with the
+// error of the context. The rest of the channel is drained in the background,
+// so that goroutines writing to it are not blocked.
+func DrainErrors(ctx context.Context, ch <-chan error) ([]error, error) {
+	var errs = make([]error, 0)
+	for {
+		select {
+		case err, ok := <-ch:
+			if !ok {
+				return errs, nil
+			}
+			if err != nil {
+				errs = append(errs, err)
+			}
+		case <-ctx.Done():
+			go func() {
+				for range ch {
+				}
+			}()
+			return errs, ctx.Err()
+		}
+	}
+}
+
+// Read the errors from a channel returned by SendAsync, until it is closed.
+//
+// Returns an Error containing all non-nil errors read from the channel,
+// or nil if there were none. This matches the error returned by Send.
+func Wait(ch <-chan error) error {
+	var errs = make([]error, 0)
+	var truncated int
+	for err := range ch {
+		switch {
+		case err == nil:
+		case MaxCollectedErrors > 0 && len(errs) >= MaxCollectedErrors:
+			truncated++
+		default:
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 && truncated == 0 {
+		return nil
+	}
+	return Error{
+		Val:            fmt.Sprintf("error sending signal to %d receivers", len(errs)+truncated),
+		Errors:         errs,
+		TruncatedCount: truncated,
+	}
+}
+
+// Send a signal to all receivers asynchronously, and wait for them to complete.
+//
+// Returns an Error containing the errors of all receivers, or nil if there were none.
+func (s *signal[T]) SendAsyncWait(value T) error {
+	return Wait(s.SendAsync(value))
+}
+
+// Receiver which is called in its own goroutine, after all other receivers.
+type asyncReceiver[T any] struct {
+	Receiver[T]
+}
+
+// Start receiving the value in a new goroutine, and return immediately.
+//
+// Errors returned by the receiver are passed to the error hook of the signal.
+func (r *asyncReceiver[T]) Receive(s Signal[T], value T) error {
+	var signal, ok = s.(*signal[T])
+	go func() {
+		if !ok {
+			r.Receiver.Receive(s, value)
+			return
+		}
+		if err := signal.call(r.Receiver, value); err != nil {
+			signal.handleError(err)
+		}
+	}()
+	return nil
+}
+
+// Start receiving the value with the context in a new goroutine, and return immediately.
+//
+// The context is passed on if the wrapped receiver implements ContextReceiver.
+// The send does not wait for the receiver, the context may be done by the time it is called.
+func (r *asyncReceiver[T]) ReceiveContext(ctx context.Context, s Signal[T], value T) error {
+	var signal, ok = s.(*signal[T])
+	go func() {
+		if !ok {
+			receiveContext(ctx, r.Receiver, s, value)
+			return
+		}
+		if err := signal.callContext(ctx, r.Receiver, value); err != nil {
+			signal.handleError(err)
+		}
+	}()
+	return nil
+}
+
+func (r *asyncReceiver[T]) unwrap() Receiver[T] {
+	return r.Receiver
+}
+
+func isAsync[T any](receiver Receiver[T]) bool {
+	var _, ok = receiver.(*asyncReceiver[T])
+	return ok
+}
+
+// Connect receivers which are called asynchronously.
+//
+// When a value is sent, all other receivers are called first and their errors
+// are returned by the send. The asynchronous receivers are then each started
+// in their own goroutine, the send does not wait for them to complete.
+//
+// Errors returned by asynchronous receivers are passed to the error hook.
+func (s *signal[T]) ConnectAsync(receivers ...Receiver[T]) error {
+	var async = make([]Receiver[T], len(receivers))
+	for i, receiver := range receivers {
+		if receiver == nil {
+			return wrap(ErrNilReceiver)
+		}
+		async[i] = &asyncReceiver[T]{Receiver: receiver}
+	}
+	return s.Connect(async...)
+}
+
+// Result of sending a value to a single receiver asynchronously.
+type AsyncResult struct {
+	// Sequence number of the send, assigned when the send was started.
+	//
+	// Sequence numbers are unique per signal and increase with every send,
+	// they can be used to detect or restore the order of sends.
+	Seq uint64
+	// The ID of the receiver, or 0 if the value was never sent.
+	ReceiverID uint64
+	// The error returned by the receiver, or the error which prevented the value from being sent.
+	Err error
+}
+
+// Send a signal to all receivers asynchronously, with sequence numbers.
+//
+// This behaves like SendAsync, but every result carries the sequence number
+// of the send and the ID of the receiver it came from.
+//
+// The sequence number is assigned before this function returns,
+// so sends from a single goroutine always have increasing sequence numbers.
+//
+// Returns a channel which will contain a result for each receiver, it is closed once all receivers have returned.
+func (s *signal[T]) SendAsyncResults(value T) chan AsyncResult {
+	var seq = s.seq.Add(1)
+
+	value, ok, err := s.prepare(value)
+	if !ok {
+		return closedResult(AsyncResult{Seq: seq, Err: err})
+	}
+
+	receivers, err := s.snapshot()
+	if err != nil {
+		return closedResult(AsyncResult{Seq: seq, Err: err})
+	}
+
+	if len(receivers) == 0 {
+		return closedResult(AsyncResult{Seq: seq, Err: s.noReceivers()})
+	}
+
+	var results = make(chan AsyncResult, len(receivers))
+	var wg sync.WaitGroup
+	wg.Add(len(receivers))
+	for _, receiver := range receivers {
+		go func(receiver Receiver[T]) {
+			defer wg.Done()
+			results <- AsyncResult{
+				Seq:        seq,
+				ReceiverID: receiver.ID(),
+				Err:        s.call(receiver, value),
+			}
+		}(receiver)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
+
+// Return a closed channel containing the result, if it has an error.
+func closedResult(result AsyncResult) chan AsyncResult {
+	var results = make(chan AsyncResult, 1)
+	if result.Err != nil {
+		results <- result
+	}
+	close(results)
+	return results
+}
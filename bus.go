@@ -0,0 +1,71 @@
+package signals
+
+import (
+	"sort"
+	"strings"
+)
+
+// Event bus for publishing values to topics.
+//
+// The bus is a simpler interface over a pool of signals,
+// each topic is a signal inside of the pool with the same name.
+type EventBus[T any] struct {
+	pool *Pool[T]
+}
+
+// Return a new event bus over the pool.
+//
+// If the pool is nil, a new pool is created.
+func NewEventBus[T any](pool *Pool[T]) *EventBus[T] {
+	if pool == nil {
+		pool = NewPool[T]()
+	}
+	return &EventBus[T]{pool: pool}
+}
+
+// Return the pool the event bus publishes to.
+func (b *EventBus[T]) Pool() *Pool[T] {
+	return b.pool
+}
+
+// Publish a value to all subscribers of the topic.
+//
+// Publishing to a topic without any subscribers is a no-op.
+//
+// Returns the errors of the receivers connected to the topic's signal.
+func (b *EventBus[T]) Publish(topic string, v T) error {
+	var signal, ok = b.pool.load(topic)
+	if !ok || !signal.HasReceivers() {
+		return nil
+	}
+	return b.pool.send(signal, v)
+}
+
+// Subscribe to a topic with a function.
+//
+// The returned function unsubscribes from the topic.
+//
+// Returns an error if the topic could not be subscribed to,
+// e.g. when the pool is in strict mode and the topic has not been declared.
+func (b *EventBus[T]) SubscribeFunc(topic string, cb func(T)) (unsub func(), err error) {
+	receiver, err := b.pool.Listen(topic, func(signal Signal[T], value T) error {
+		cb(value)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return func() {
+		receiver.Disconnect()
+	}, nil
+}
+
+// Return the sorted names of the topics on the bus.
+func (b *EventBus[T]) Topics() []string {
+	var topics = make([]string, 0)
+	for _, signal := range b.pool.snapshot() {
+		topics = append(topics, strings.TrimPrefix(signal.Name(), b.pool.prefix))
+	}
+	sort.Strings(topics)
+	return topics
+}
@@ -0,0 +1,83 @@
+package signals_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/go-signals"
+)
+
+func TestEventBus(t *testing.T) {
+	var pool = signals.NewPool[string](signals.RequireReceivers[string]())
+	var bus = signals.NewEventBus(pool)
+
+	var received = make(map[string][]string)
+	var unsubCreated, err = bus.SubscribeFunc("created", func(value string) {
+		received["created"] = append(received["created"], value)
+	})
+	if err != nil {
+		t.Fatalf("Expected no errors subscribing, got %s", err.Error())
+	}
+	if _, err := bus.SubscribeFunc("deleted", func(value string) {
+		received["deleted"] = append(received["deleted"], value)
+	}); err != nil {
+		t.Fatalf("Expected no errors subscribing, got %s", err.Error())
+	}
+
+	if err := bus.Publish("created", "alice"); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	if err := bus.Publish("deleted", "bob"); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	if err := bus.Publish("unknown", "carol"); err != nil {
+		t.Errorf("Expected publishing to an unknown topic to be a no-op, got %s", err.Error())
+	}
+
+	if strings.Join(received["created"], ",") != "alice" || strings.Join(received["deleted"], ",") != "bob" {
+		t.Errorf("Expected each topic to only receive its own values, got %v", received)
+	}
+
+	if topics := bus.Topics(); strings.Join(topics, ",") != "created,deleted" {
+		t.Errorf("Expected topics created and deleted, got %v", topics)
+	}
+
+	unsubCreated()
+	if err := bus.Publish("created", "dave"); err != nil {
+		t.Errorf("Expected publishing without subscribers to be a no-op, got %s", err.Error())
+	}
+	if len(received["created"]) != 1 {
+		t.Errorf("Expected no values after unsubscribing, got %v", received["created"])
+	}
+
+	if err := pool.Send("created", "erin"); !errors.Is(err, signals.ErrNoReceivers) {
+		t.Errorf("Expected the pool to share its signals with the bus, got %v", err)
+	}
+	pool.Listen("deleted", func(signal signals.Signal[string], value string) error {
+		return errors.New("pool receiver failed")
+	})
+	if err := bus.Publish("deleted", "frank"); err == nil {
+		t.Error("Expected errors of receivers connected through the pool to be returned")
+	}
+}
+
+func TestEventBusErrors(t *testing.T) {
+	var pool = signals.NewPool[string](signals.RequireReceivers[string]())
+	var bus = signals.NewEventBus(pool)
+
+	// A subscriber failing while forwarding to a topic without subscribers is reported.
+	var errFailed = errors.New("failed")
+	pool.Get("forwarding").Connect(signals.NewPoolForwardRecv(pool, "empty"))
+	pool.Listen("forwarding", func(signal signals.Signal[string], value string) error {
+		return errFailed
+	})
+	if err := bus.Publish("forwarding", "value"); !errors.Is(err, errFailed) {
+		t.Errorf("Expected the subscriber's error to be returned, got %v", err)
+	}
+
+	pool.SetStrictMode(true)
+	if unsub, err := bus.SubscribeFunc("undeclared", func(value string) {}); !errors.Is(err, signals.ErrSignalNotFound) || unsub != nil {
+		t.Errorf("Expected subscribing to an undeclared topic in strict mode to fail, got %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package signals
+
+import (
+	"context"
+	"sync"
+)
+
+// Send a value on the signal when the context is done.
+//
+// A goroutine is started which waits for the context to be done,
+// and then sends the value to the signal's receivers once.
+//
+// The returned function stops watching the context, if the value
+// has not been sent yet it will not be sent anymore.
+//
+// Errors returned by the receivers are passed to the error hook of the signal.
+func SignalOnCancel[T any](ctx context.Context, s Signal[T], value T) (stop func()) {
+	var done = make(chan struct{})
+	var once sync.Once
+	go func() {
+		select {
+		case <-ctx.Done():
+			// Do not send if stopped at the same time.
+			select {
+			case <-done:
+				return
+			default:
+			}
+			var err = s.Send(value)
+			if sig, ok := s.(*signal[T]); ok {
+				sig.handleError(err)
+			}
+		case <-done:
+		}
+	}()
+
+	return func() {
+		once.Do(func() {
+			close(done)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package signals
+
+import (
+	"context"
+	"fmt"
+)
+
+// Event for a receiver which was connected to or disconnected from a signal.
+type ReceiverChangeEvent struct {
+	// The name of the signal.
+	Signal string
+	// The ID of the receiver.
+	ReceiverID uint64
+	// True if the receiver was connected, false if it was disconnected.
+	Connected bool
+}
+
+// Call the change hook for each of the receivers.
+//
+// The signal must not be locked when calling this.
+func (s *signal[T]) notifyChange(receivers []Receiver[T], connected bool) {
+	if s.changeHook == nil {
+		return
+	}
+	for _, receiver := range receivers {
+		s.changeHook(ReceiverChangeEvent{
+			Signal:     s.name,
+			ReceiverID: receiver.ID(),
+			Connected:  connected,
+		})
+	}
+}
+
+// Add a function which is called when a receiver is connected to
+// or disconnected from any of the signals inside of the pool.
+//
+// The function is called after the change has been made,
+// no locks are held while it is called.
+//
+// Receivers moved to a new signal by Reconfigure are not reported.
+//
+// The functions are shared with the namespaces of the pool.
+func (m *Pool[T]) OnReceiverChange(fn func(event ReceiverChangeEvent)) {
+	var c = m.config()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.changeHooks = append(c.changeHooks, fn)
+}
+
+// Call the functions added with OnReceiverChange,
+// and wake up the goroutines waiting in WaitForReceiver.
+func (m *Pool[T]) notifyReceiverChange(event ReceiverChangeEvent) {
+	var c = m.config()
+	c.mu.RLock()
+	var hooks = c.changeHooks
+	for waiter := range c.waiters {
+		select {
+		case waiter <- struct{}{}:
+		default:
+		}
+	}
+	c.mu.RUnlock()
+
+	for _, hook := range hooks {
+		hook(event)
+	}
+}
+
+// Wait until the signal with the given name has at least one receiver.
+//
+// The signal does not need to exist yet, it may be created while waiting.
+//
+// Returns the error of the context, if it is done before a receiver is connected.
+func (m *Pool[T]) WaitForReceiver(ctx context.Context, name string) error {
+	var c = m.config()
+	var waiter = make(chan struct{}, 1)
+	c.mu.Lock()
+	if c.waiters == nil {
+		c.waiters = make(map[chan struct{}]struct{})
+	}
+	c.waiters[waiter] = struct{}{}
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.waiters, waiter)
+		c.mu.Unlock()
+	}()
+
+	for !m.HasReceivers(name) {
+		select {
+		case <-ctx.Done():
+			return Error{
+				Val: fmt.Sprintf("stopped waiting for a receiver on signal %q", m.prefix+name),
+				Err: ctx.Err(),
+			}
+		case <-waiter:
+		}
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package signals
+
+import "time"
+
+// Clock interface.
+//
+// Used by time-based receivers to tell the time,
+// this allows for a fake clock to be used in tests, see WithClock.
+type Clock interface {
+	// Return the current time.
+	Now() time.Time
+	// Return a channel which receives the current time after the duration has passed.
+	After(time.Duration) <-chan time.Time
+}
+
+// Default clock used by time-based receivers.
+//
+// This uses the time package to tell the time.
+var RealClock Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
@@ -0,0 +1,104 @@
+package signals
+
+import (
+	"context"
+	"fmt"
+)
+
+// Receivers which return a result when receiving a value.
+//
+// Results can be gathered by sending a value with Collect.
+type ResultReceiver[T, R any] interface {
+	Receiver[T]
+
+	// Receives the signal and value from the signal, and returns a result.
+	ReceiveResult(Signal[T], T) (R, error)
+}
+
+// Underlying receiver struct for the ResultReceiver interface.
+type funcReceiver[T, R any] struct {
+	*receiver[T]
+	cb func(Signal[T], T) (R, error)
+}
+
+// Initialize a new receiver which returns a result.
+//
+// When the value is sent with Send, the result is discarded.
+//
+// When the value is sent with Collect, the result is gathered.
+func NewFuncRecv[T, R any](cb func(Signal[T], T) (R, error), opts ...RecvOption) ResultReceiver[T, R] {
+	var r = &funcReceiver[T, R]{cb: cb}
+	r.receiver = NewRecv(func(s Signal[T], value T) error {
+		var _, err = cb(s, value)
+		return err
+	}, opts...)
+	return r
+}
+
+// Receives the signal and value from the signal, and returns a result.
+func (r *funcReceiver[T, R]) ReceiveResult(s Signal[T], value T) (R, error) {
+	return r.cb(s, value)
+}
+
+// Receiver which passes the result of a ResultReceiver to a function.
+type collector[T, R any] struct {
+	ResultReceiver[T, R]
+	collect func(R)
+}
+
+// Receives the signal and value from the signal, and collects the result.
+func (r *collector[T, R]) Receive(s Signal[T], value T) error {
+	var out, err = r.ReceiveResult(s, value)
+	if err != nil {
+		return err
+	}
+	r.collect(out)
+	return nil
+}
+
+// Return the receiver with the ResultReceiver it wraps replaced by a collector,
+// the receiver itself is returned if it does not wrap a ResultReceiver.
+func collecting[T, R any](receiver Receiver[T], collect func(R)) (Receiver[T], bool) {
+	switch r := receiver.(type) {
+	case ResultReceiver[T, R]:
+		return &collector[T, R]{ResultReceiver: r, collect: collect}, true
+	case wrapper[T]:
+		if inner, ok := collecting(r.unwrap(), collect); ok {
+			return r.rewrap(inner), true
+		}
+	}
+	return receiver, false
+}
+
+// Send a value to all receivers of the signal, gathering the results.
+//
+// Results are gathered from receivers implementing ResultReceiver[T, R],
+// in the order the receivers are called. Other receivers still receive the value.
+// Receivers wrapping a ResultReceiver, such as receivers connected with ConnectPriority
+// or ConnectFilter, are gathered as well. Receivers connected with ConnectAsync are not.
+//
+// Results of receivers which returned an error are not gathered.
+//
+// The value is sent like it is sent by Send, including the fallback receiver.
+//
+// Returns an error, if any of the receivers return an error.
+//
+// The signal must have been created by this package.
+func Collect[T, R any](s Signal[T], value T) ([]R, error) {
+	var sig, ok = s.(*signal[T])
+	if !ok {
+		return nil, e(fmt.Sprintf("cannot collect results from signal of type %T", s))
+	}
+
+	var results = make([]R, 0)
+	var collect = func(out R) {
+		results = append(results, out)
+	}
+	var result = sig.sendContext(context.Background(), value, sendHooks[T]{
+		replace: func(receiver Receiver[T]) Receiver[T] {
+			receiver, _ = collecting[T, R](receiver, collect)
+			return receiver
+		},
+	})
+	return results, result.Err()
+}
@@ -0,0 +1,40 @@
+package signals
+
+import "fmt"
+
+// Declarative description of the signals in a pool, and their receivers.
+type PoolConfig struct {
+	Signals []SignalConfig `json:"signals"`
+}
+
+// Description of a signal, with its receivers referenced by their registered name.
+type SignalConfig struct {
+	Name      string   `json:"name"`
+	Receivers []string `json:"receivers"`
+}
+
+// Create the signals described in the config, and connect the referenced receivers.
+//
+// Receivers are looked up by name in the registry, if any name is not registered
+// an error is returned before any signal is created or connected.
+//
+// Signals which already exist in the pool are reused.
+func (m *Pool[T]) Apply(cfg PoolConfig, registry map[string]func(Signal[T], T) error) error {
+	for _, s := range cfg.Signals {
+		for _, name := range s.Receivers {
+			if _, ok := registry[name]; !ok {
+				return e(fmt.Sprintf("no receiver registered as %q for signal %q", name, s.Name))
+			}
+		}
+	}
+
+	for _, s := range cfg.Signals {
+		var signal = m.Declare(s.Name)
+		for _, name := range s.Receivers {
+			if _, err := signal.Listen(registry[name]); err != nil {
+				return Error{Val: fmt.Sprintf("error connecting receiver %q to signal %q", name, s.Name), Err: err}
+			}
+		}
+	}
+	return nil
+}
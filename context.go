@@ -0,0 +1,54 @@
+package signals
+
+import "context"
+
+// Receiver which receives the context of the send.
+type contextReceiver[T any] struct {
+	*receiver[T]
+	cb func(context.Context, Signal[T], T) error
+}
+
+// Initialize a new receiver which receives the context of the send.
+//
+// When the value is sent with SendContext, the context of the send is passed
+// to the callback. Otherwise, the callback receives context.Background().
+//
+// Options can be provided to configure the receiver.
+func NewContextRecv[T any](cb func(context.Context, Signal[T], T) error, opts ...RecvOption) Receiver[T] {
+	var r = &contextReceiver[T]{cb: cb}
+	r.receiver = NewRecv(func(s Signal[T], value T) error {
+		return cb(context.Background(), s, value)
+	}, opts...)
+	return r
+}
+
+// Receives the context, signal and value from the signal.
+func (r *contextReceiver[T]) ReceiveContext(ctx context.Context, s Signal[T], value T) error {
+	return r.cb(ctx, s, value)
+}
+
+// Send a signal to all receivers, until the context is done.
+//
+// Receivers implementing ContextReceiver receive the context.
+//
+// If the context is done during the send, the remaining receivers are not called.
+// The returned error wraps the error of the context,
+// and the errors of the receivers which were already called.
+//
+// The default timeout of the signal does not apply, the context's deadline is used instead.
+//
+// If the signal has a context extractor, the values it extracts from
+// the context are attached to the returned Error as its Context.
+func (s *signal[T]) SendContext(ctx context.Context, value T) error {
+	var err = s.sendContext(ctx, value, sendHooks[T]{}).Err()
+	if err == nil || s.contextExtractor == nil {
+		return err
+	}
+
+	var signalErr, ok = err.(Error)
+	if !ok {
+		signalErr = Error{Val: err.Error(), Err: err}
+	}
+	signalErr.Context = s.contextExtractor(ctx)
+	return signalErr
+}
@@ -0,0 +1,74 @@
+package signals
+
+import (
+	"sync"
+	"time"
+)
+
+// Initialize a new debouncing receiver.
+//
+// The callback will only be called once no new values
+// have been received for the duration of wait.
+//
+// It will be called with the last value that was received.
+//
+// A single timer is kept while values are being received,
+// it is pushed back instead of starting a new one for every value.
+//
+// Errors returned by the callback are passed to the error hook of the signal,
+// as the signal will already have been sent by the time the callback is called.
+//
+// Options can be provided to configure the receiver,
+// WithClock sets the clock used to wait.
+func NewDebounceRecv[T any](wait time.Duration, cb func(Signal[T], T) error, opts ...RecvOption) Receiver[T] {
+	var (
+		r        *receiver[T]
+		mu       sync.Mutex
+		latest   T
+		deadline time.Time
+		waiting  bool
+	)
+
+	// Wait until no values have been received for the duration of wait,
+	// and call the callback with the last value.
+	var debounce = func(s Signal[T], after <-chan time.Time) {
+		for {
+			var now = <-after
+
+			mu.Lock()
+			if remaining := deadline.Sub(now); remaining > 0 {
+				after = r.opts.clock.After(remaining)
+				mu.Unlock()
+				continue
+			}
+			var value = latest
+			var zero T
+			latest = zero
+			waiting = false
+			mu.Unlock()
+
+			if err := cb(s, value); err != nil {
+				if s, ok := s.(*signal[T]); ok {
+					s.handleError(err)
+				}
+			}
+			return
+		}
+	}
+
+	r = NewRecv(func(s Signal[T], value T) error {
+		mu.Lock()
+		defer mu.Unlock()
+		latest = value
+		deadline = r.opts.clock.Now().Add(wait)
+		if !waiting {
+			waiting = true
+
+			// Register the timer before returning, so that
+			// the wait starts at the time the value was received.
+			go debounce(s, r.opts.clock.After(wait))
+		}
+		return nil
+	}, opts...)
+	return r
+}
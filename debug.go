@@ -0,0 +1,135 @@
+package signals
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// Detect goroutines attempting to lock a signal they already hold.
+//
+// Instead of hanging forever, the goroutine will panic with
+// a descriptive message and the stack trace of the goroutine.
+//
+// This adds overhead to every lock, and should only be enabled during development.
+var DebugLocks bool
+
+// Detect receivers of a signal being connected or disconnected while a value is sent.
+//
+// A send calls a copy of the receivers, receivers connected or disconnected during
+// the send are only affected from the next send onwards. Code which expects otherwise
+// misses values, or receives values after it was disconnected. When enabled, the slice
+// header and the length of the receivers are recorded before a send, and the send
+// panics if they changed once it completes.
+//
+// This includes receivers which disconnect themselves, such as those created by ListenOnce.
+//
+// This adds overhead to every send, and should only be enabled during development.
+var DebugRace bool
+
+// Slice header of the receivers of a signal, recorded when DebugRace is enabled.
+type receiversHeader struct {
+	data unsafe.Pointer
+	len  int
+}
+
+// Return the slice header of the receivers of the signal.
+func (s *signal[T]) receiversHeader() receiversHeader {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return receiversHeader{data: unsafe.Pointer(unsafe.SliceData(s.receivers)), len: len(s.receivers)}
+}
+
+// Record the slice header of the receivers before a send, if DebugRace is enabled.
+func (s *signal[T]) recordReceivers() *receiversHeader {
+	if !DebugRace {
+		return nil
+	}
+	var header = s.receiversHeader()
+	return &header
+}
+
+// Panic if the receivers no longer match the slice header recorded before the send.
+func (s *signal[T]) assertReceivers(recorded *receiversHeader) {
+	if header := s.receiversHeader(); header != *recorded {
+		panic(fmt.Sprintf(
+			"signals: receivers of signal %q were modified during send, %d receivers before and %d after",
+			s.name, recorded.len, header.len,
+		))
+	}
+}
+
+// Mutex which keeps track of the goroutine holding it when DebugLocks is enabled.
+//
+// Only the goroutine holding the write lock is tracked.
+type mutex struct {
+	mu    sync.RWMutex
+	owner atomic.Int64
+}
+
+// Lock the mutex.
+//
+// Panics if DebugLocks is enabled and the calling goroutine already holds the mutex.
+func (m *mutex) Lock() {
+	if !DebugLocks {
+		m.mu.Lock()
+		return
+	}
+
+	var id = goroutineID()
+	if m.owner.Load() == id {
+		panic(fmt.Sprintf(
+			"signals: goroutine %d attempted to lock a signal it already holds, this would deadlock\n\n%s",
+			id, debug.Stack(),
+		))
+	}
+
+	m.mu.Lock()
+	m.owner.Store(id)
+}
+
+// Unlock the mutex.
+func (m *mutex) Unlock() {
+	m.owner.Store(0)
+	m.mu.Unlock()
+}
+
+// Lock the mutex for reading.
+//
+// Panics if DebugLocks is enabled and the calling goroutine already holds the write lock.
+func (m *mutex) RLock() {
+	if DebugLocks {
+		var id = goroutineID()
+		if m.owner.Load() == id {
+			panic(fmt.Sprintf(
+				"signals: goroutine %d attempted to read lock a signal it already holds, this would deadlock\n\n%s",
+				id, debug.Stack(),
+			))
+		}
+	}
+	m.mu.RLock()
+}
+
+// Unlock the mutex for reading.
+func (m *mutex) RUnlock() {
+	m.mu.RUnlock()
+}
+
+// Return the ID of the calling goroutine.
+//
+// This is parsed from the header of the goroutine's stack trace, "goroutine 1 [running]:".
+func goroutineID() int64 {
+	var buf [64]byte
+	var b = buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	var id, _ = strconv.ParseInt(string(b), 10, 64)
+	return id
+}
@@ -0,0 +1,218 @@
+package signals
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Check if a receiver has declared any dependencies.
+//
+// Wrapped receivers are checked for the dependencies of the receiver they wrap.
+func hasDependencies[T any](receiver Receiver[T]) bool {
+	var dependent, ok = unwrapAs[Dependent](receiver)
+	return ok && len(dependent.DependsOn()) > 0
+}
+
+// Return the priority of a receiver, or 0 if it has none.
+//
+// Wrapped receivers have the priority of the receiver they wrap.
+func receiverPriority[T any](receiver Receiver[T]) int {
+	if prioritized, ok := unwrapAs[Prioritized](receiver); ok {
+		return prioritized.Priority()
+	}
+	return 0
+}
+
+// Sort the receivers by their priorities again before the next send.
+//
+// This is called by receivers when their priority changes.
+func (s *signal[T]) reprioritize() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prioritized = true
+	s.order = nil
+}
+
+// Return the key of a receiver, or an empty string if it has none.
+//
+// Wrapped receivers have the key of the receiver they wrap.
+func receiverKey[T any](receiver Receiver[T]) string {
+	if keyed, ok := unwrapAs[Keyed](receiver); ok {
+		return keyed.Key()
+	}
+	return ""
+}
+
+// Return the receivers in the order they should be called in.
+//
+// If any receivers have a priority, the receivers are sorted by their priority,
+// receivers with a higher priority are called first.
+//
+// If any receivers declared dependencies, the receivers are sorted so that
+// each receiver is called after the receivers it depends on.
+// Receivers without dependencies keep the order they were connected in.
+//
+// The sorted order is cached until the receivers or their priorities change.
+//
+// If the receivers are shuffled, a shuffled copy of the receivers is sorted
+// each time this is called, instead of using the cache.
+//
+// If an order function is set, it is applied to a copy of the receivers
+// each time this is called, before they are sorted by their dependencies.
+// This way the order function can not move a receiver before a receiver it depends on.
+//
+// The signal must be locked when calling this.
+func (s *signal[T]) ordered() ([]Receiver[T], error) {
+	var receivers = s.receivers
+	if s.order != nil && s.rng == nil && s.orderFunc == nil {
+		receivers = s.order
+	} else {
+		var sorted bool
+		if s.rng != nil {
+			receivers = append(make([]Receiver[T], 0, len(receivers)), receivers...)
+			s.rng.Shuffle(len(receivers), func(i, j int) {
+				receivers[i], receivers[j] = receivers[j], receivers[i]
+			})
+			sorted = true
+		}
+
+		if s.prioritized {
+			if !sorted {
+				receivers = append(make([]Receiver[T], 0, len(receivers)), receivers...)
+			}
+			sort.SliceStable(receivers, func(i, j int) bool {
+				return receiverPriority(receivers[i]) > receiverPriority(receivers[j])
+			})
+			sorted = true
+		}
+
+		if s.orderFunc != nil {
+			var less = s.orderFunc
+			if !sorted {
+				receivers = append(make([]Receiver[T], 0, len(receivers)), receivers...)
+			}
+			sort.SliceStable(receivers, func(i, j int) bool {
+				return less(receivers[i], receivers[j])
+			})
+			sorted = true
+		}
+
+		if s.dependents > 0 {
+			var order, err = sortDependencies(receivers)
+			if err != nil {
+				return nil, err
+			}
+			receivers = order
+			sorted = true
+		}
+
+		// The order function is called before each send, its order is not cached.
+		if sorted && s.rng == nil && s.orderFunc == nil {
+			s.order = receivers
+		}
+	}
+
+	// Asynchronous receivers are always called last,
+	// so that the other receivers complete before the send returns.
+	if s.asyncs > 0 {
+		var partitioned = make([]Receiver[T], 0, len(receivers))
+		for _, receiver := range receivers {
+			if !isAsync(receiver) {
+				partitioned = append(partitioned, receiver)
+			}
+		}
+		for _, receiver := range receivers {
+			if isAsync(receiver) {
+				partitioned = append(partitioned, receiver)
+			}
+		}
+		receivers = partitioned
+	}
+
+	return receivers, nil
+}
+
+// Set the function used to order the receivers before each send.
+//
+// The function reports whether receiver a should be called before receiver b.
+// Receivers for which the order is equal keep their previous order.
+// Receivers are still called after the receivers they depend on.
+//
+// Setting the function to nil restores the default order.
+func (s *signal[T]) SetOrderFunc(less func(a, b Receiver[T]) bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orderFunc = less
+}
+
+// Sort the receivers by their dependencies.
+//
+// Returns an error if the dependencies of the receivers form a cycle.
+func sortDependencies[T any](receivers []Receiver[T]) ([]Receiver[T], error) {
+	var byKey = make(map[string][]int)
+	for i, receiver := range receivers {
+		if key := receiverKey(receiver); key != "" {
+			byKey[key] = append(byKey[key], i)
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	var (
+		state = make([]int, len(receivers))
+		order = make([]Receiver[T], 0, len(receivers))
+		path  = make([]string, 0)
+		visit func(i int) error
+	)
+
+	visit = func(i int) error {
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			path = append(path, receiverName(receivers[i]))
+			return Error{
+				Val: fmt.Sprintf("dependency cycle between receivers: %s", strings.Join(path, " -> ")),
+				Err: ErrDependencyCycle,
+			}
+		}
+
+		state[i] = visiting
+		path = append(path, receiverName(receivers[i]))
+		if dependent, ok := unwrapAs[Dependent](receivers[i]); ok {
+			for _, key := range dependent.DependsOn() {
+				for _, j := range byKey[key] {
+					if err := visit(j); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = visited
+
+		order = append(order, receivers[i])
+		return nil
+	}
+
+	for i := range receivers {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
+
+// Return a human readable name for a receiver, used in error messages.
+func receiverName[T any](receiver Receiver[T]) string {
+	if key := receiverKey(receiver); key != "" {
+		return key
+	}
+	return fmt.Sprintf("receiver %d", receiver.ID())
+}
@@ -0,0 +1,67 @@
+package signals
+
+import "context"
+
+// Receiver which transforms the errors of another receiver.
+type errMapReceiver[T any] struct {
+	Receiver[T]
+	mapErr func(error) error
+}
+
+// Initialize a new receiver which transforms the errors returned by the inner receiver.
+//
+// Any error returned by the inner receiver is passed through mapErr,
+// before it is returned to the signal.
+//
+// If mapErr returns nil, the error is swallowed.
+//
+// The receiver shares its ID with the inner receiver.
+func NewErrMapRecv[T any](mapErr func(error) error, inner Receiver[T]) Receiver[T] {
+	return &errMapReceiver[T]{Receiver: inner, mapErr: mapErr}
+}
+
+// Receives the signal and value from the signal.
+func (r *errMapReceiver[T]) Receive(s Signal[T], value T) error {
+	return mapError(r.mapErr, r.Receiver.Receive(s, value))
+}
+
+// Receives the context, signal and value from the signal.
+//
+// The context is passed on if the inner receiver implements ContextReceiver.
+func (r *errMapReceiver[T]) ReceiveContext(ctx context.Context, s Signal[T], value T) error {
+	return mapError(r.mapErr, receiveContext(ctx, r.Receiver, s, value))
+}
+
+func (r *errMapReceiver[T]) unwrap() Receiver[T] {
+	return r.Receiver
+}
+
+func (r *errMapReceiver[T]) rewrap(inner Receiver[T]) Receiver[T] {
+	return &errMapReceiver[T]{Receiver: inner, mapErr: r.mapErr}
+}
+
+// Transactional receiver which transforms the errors of the transactional receiver it wraps.
+type errMapTx[T any] struct {
+	TxReceiver[T]
+	mapErr func(error) error
+}
+
+func (r *errMapTx[T]) Prepare(s Signal[T], value T) error {
+	return mapError(r.mapErr, r.TxReceiver.Prepare(s, value))
+}
+
+func (r *errMapTx[T]) Commit() error {
+	return mapError(r.mapErr, r.TxReceiver.Commit())
+}
+
+func (r *errMapTx[T]) Rollback() error {
+	return mapError(r.mapErr, r.TxReceiver.Rollback())
+}
+
+// Pass the error through mapErr, nil errors are not mapped.
+func mapError(mapErr func(error) error, err error) error {
+	if err != nil {
+		return mapErr(err)
+	}
+	return nil
+}
@@ -1,5 +1,51 @@
 package signals
 
+import "errors"
+
+var (
+	// Returned when a signal could not be found inside of a pool.
+	ErrSignalNotFound = errors.New("signal not found")
+
+	// Returned when a signal which requires receivers is sent, but no receivers are connected.
+	ErrNoReceivers = errors.New("no receivers")
+
+	// Returned when a receiver is disconnected, but not connected to a signal.
+	ErrNotConnected = errors.New("receiver is not connected to a signal")
+
+	// Returned when a nil receiver is connected to a signal.
+	ErrNilReceiver = errors.New("receiver is nil")
+
+	// Returned when a nil value is sent on a signal which rejects nil values.
+	ErrNilValue = errors.New("value is nil")
+
+	// Returned when receivers are connected to or disconnected from a frozen signal.
+	ErrFrozen = errors.New("signal is frozen")
+
+	// Returned when the receivers of a signal do not complete within the timeout.
+	ErrTimeout = errors.New("send timed out")
+
+	// Returned when a signal is cleared while a value is being sent.
+	ErrSignalCleared = errors.New("signal was cleared during send")
+
+	// Returned when a value of a different type is sent on a type locked signal.
+	ErrTypeMismatch = errors.New("value has a different type")
+
+	// Returned when a receiver panicked, and panics are recovered.
+	ErrReceiverPanic = errors.New("receiver panicked")
+
+	// Returned when the dependencies between receivers form a cycle.
+	ErrDependencyCycle = errors.New("dependency cycle between receivers")
+
+	// Returned when a signal is used after it was deleted from its pool.
+	ErrSignalDeleted = errors.New("signal was deleted")
+
+	// Returned when forwarding from one signal to another would form a cycle.
+	ErrForwardCycle = errors.New("forward cycle between signals")
+
+	// Returned when a frame in the wire format is larger than MaxWireFrameSize.
+	ErrFrameTooLarge = errors.New("frame is too large")
+)
+
 func SignalError(e error) (Error, bool) {
 	switch e := e.(type) {
 	case Error:
@@ -13,10 +59,24 @@ func e(val string, errors ...error) error {
 	return Error{Val: val, Errors: errors}
 }
 
+// Wrap a sentinel error inside of an Error.
+//
+// The message of the sentinel will be used as the message of the Error.
+func wrap(err error, errors ...error) error {
+	return Error{Val: err.Error(), Err: err, Errors: errors}
+}
+
 // Error type for signals.
 type Error struct {
 	Val    string
+	Err    error
 	Errors []error
+
+	// The amount of errors which were dropped from Errors, see MaxCollectedErrors.
+	TruncatedCount int
+
+	// Values extracted from the context of the send, see WithContextExtractor.
+	Context map[string]string
 }
 
 func (e Error) Error() string {
@@ -26,3 +86,16 @@ func (e Error) Error() string {
 func (e Error) Len() int {
 	return len(e.Errors)
 }
+
+// Return the underlying errors, this allows for errors.Is and errors.As to be used.
+//
+// The cause of the error (if any) will be returned first,
+// followed by the errors returned by the receivers.
+func (e Error) Unwrap() []error {
+	if e.Err == nil {
+		return e.Errors
+	}
+	var errs = make([]error, 0, len(e.Errors)+1)
+	errs = append(errs, e.Err)
+	return append(errs, e.Errors...)
+}
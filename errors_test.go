@@ -0,0 +1,112 @@
+package signals_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Nigel2392/go-signals"
+)
+
+func TestErrorSentinels(t *testing.T) {
+	var errorPool = signals.NewPool[string](signals.RequireReceivers[string]())
+	var signal = errorPool.Get(strconv.Itoa(int(time.Now().UnixNano())))
+
+	var err = errorPool.Send("does.not.exist", "value")
+	if !errors.Is(err, signals.ErrSignalNotFound) {
+		t.Errorf("Expected ErrSignalNotFound, got %v", err)
+	}
+
+	err = signal.Send("value")
+	if !errors.Is(err, signals.ErrNoReceivers) {
+		t.Errorf("Expected ErrNoReceivers, got %v", err)
+	}
+
+	var receiver = signals.NewRecv(func(signal signals.Signal[string], value string) error {
+		return nil
+	})
+	err = receiver.Disconnect()
+	if !errors.Is(err, signals.ErrNotConnected) {
+		t.Errorf("Expected ErrNotConnected, got %v", err)
+	}
+
+	err = signal.Connect(nil)
+	if !errors.Is(err, signals.ErrNilReceiver) {
+		t.Errorf("Expected ErrNilReceiver, got %v", err)
+	}
+
+	if _, ok := signals.SignalError(err); !ok {
+		t.Errorf("Expected a signal error, got %T", err)
+	}
+}
+
+type receiverFailure struct {
+	code int
+}
+
+func (e *receiverFailure) Error() string {
+	return "receiver failed with code " + strconv.Itoa(e.code)
+}
+
+func TestErrorUnwrapReceiverErrors(t *testing.T) {
+	var errSentinel = errors.New("sentinel")
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	signal.Listen(func(signal signals.Signal[string], value string) error {
+		return nil
+	})
+	signal.Listen(func(signal signals.Signal[string], value string) error {
+		return errSentinel
+	})
+	signal.Listen(func(signal signals.Signal[string], value string) error {
+		return &receiverFailure{code: 42}
+	})
+
+	var err = signal.Send("value")
+	if !errors.Is(err, errSentinel) {
+		t.Errorf("Expected errors.Is to find the sentinel returned by a receiver, got %v", err)
+	}
+
+	var failure *receiverFailure
+	if !errors.As(err, &failure) || failure.code != 42 {
+		t.Errorf("Expected errors.As to find the error returned by a receiver, got %v", err)
+	}
+
+	if signalErr, ok := signals.SignalError(err); !ok || signalErr.Len() != 2 {
+		t.Errorf("Expected a signal error containing 2 errors, got %v", err)
+	}
+}
+
+func TestErrNoReceivers(t *testing.T) {
+	if err := signals.New[string](strconv.Itoa(int(time.Now().UnixNano()))).Send("value"); err != nil {
+		t.Errorf("Expected sending without receivers to succeed by default, got %v", err)
+	}
+	if err := signals.NewPool[string]().CreateOrSend("created", "value"); err != nil {
+		t.Errorf("Expected sending to a newly created signal to succeed, got %v", err)
+	}
+
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())), signals.RequireReceivers[string]())
+
+	var errs = map[string]error{
+		"Send":          signal.Send("value"),
+		"SendDetailed":  signal.SendDetailed("value").Err(),
+		"SendContext":   signal.SendContext(context.Background(), "value"),
+		"SendAsync":     signals.Wait(signal.SendAsync("value")),
+		"SendAsyncN":    signals.Wait(signal.SendAsyncN(2, "value")),
+		"SendAsyncWait": signal.SendAsyncWait("value"),
+		"SendWeighted":  signal.SendWeighted("value"),
+	}
+	for method, err := range errs {
+		if !errors.Is(err, signals.ErrNoReceivers) {
+			t.Errorf("Expected %s to return ErrNoReceivers, got %v", method, err)
+		}
+	}
+
+	signal.Listen(func(s signals.Signal[string], value string) error {
+		return errors.New("failed")
+	})
+	if err := signal.Send("value"); err == nil || errors.Is(err, signals.ErrNoReceivers) {
+		t.Errorf("Expected a failing receiver not to be reported as ErrNoReceivers, got %v", err)
+	}
+}
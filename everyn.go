@@ -0,0 +1,19 @@
+package signals
+
+import "sync/atomic"
+
+// Initialize a new receiver which only calls the callback for every nth value.
+//
+// The callback is called for the nth, 2nth, 3nth... value received,
+// the other values are skipped without an error.
+//
+// An n of 1 or less calls the callback for every value.
+func NewEveryNRecv[T any](n int, cb func(Signal[T], T) error) Receiver[T] {
+	var count atomic.Uint64
+	return NewRecv(func(s Signal[T], value T) error {
+		if n > 1 && count.Add(1)%uint64(n) != 0 {
+			return nil
+		}
+		return cb(s, value)
+	})
+}
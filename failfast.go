@@ -0,0 +1,18 @@
+package signals
+
+// Initialize a new receiver which disconnects itself after its first error.
+//
+// The receiver stays connected for as long as the callback succeeds.
+// Once the callback returns an error, the receiver disconnects
+// itself from the signal and the error is returned to the signal.
+func NewFailFastRecv[T any](cb func(Signal[T], T) error) Receiver[T] {
+	var r *receiver[T]
+	r = NewRecv(func(s Signal[T], value T) error {
+		var err = cb(s, value)
+		if err != nil {
+			r.Disconnect()
+		}
+		return err
+	})
+	return r
+}
@@ -0,0 +1,51 @@
+package signals
+
+import "context"
+
+// Receiver which is only called for values matching a predicate.
+type filterReceiver[T any] struct {
+	Receiver[T]
+	filter func(T) bool
+}
+
+// Receives the signal and value from the signal, if the value matches the filter.
+//
+// Values which do not match are skipped without an error.
+func (r *filterReceiver[T]) Receive(s Signal[T], value T) error {
+	if !r.filter(value) {
+		return nil
+	}
+	return r.Receiver.Receive(s, value)
+}
+
+// Receives the context, signal and value from the signal, if the value matches the filter.
+//
+// The context is passed on if the wrapped receiver implements ContextReceiver.
+func (r *filterReceiver[T]) ReceiveContext(ctx context.Context, s Signal[T], value T) error {
+	if !r.filter(value) {
+		return nil
+	}
+	return receiveContext(ctx, r.Receiver, s, value)
+}
+
+func (r *filterReceiver[T]) unwrap() Receiver[T] {
+	return r.Receiver
+}
+
+func (r *filterReceiver[T]) rewrap(inner Receiver[T]) Receiver[T] {
+	return &filterReceiver[T]{Receiver: inner, filter: r.filter}
+}
+
+// Connect a receiver which is only called for values matching the filter.
+//
+// The filter is evaluated for every value sent, skipped values are not counted as errors.
+// If the filter is nil, the receiver is called for every value.
+func (s *signal[T]) ConnectFilter(filter func(T) bool, receiver Receiver[T]) error {
+	if receiver == nil {
+		return wrap(ErrNilReceiver)
+	}
+	if filter == nil {
+		return s.Connect(receiver)
+	}
+	return s.Connect(&filterReceiver[T]{Receiver: receiver, filter: filter})
+}
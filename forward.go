@@ -0,0 +1,202 @@
+package signals
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Directed graph of the links between signals created by ForwardTo.
+//
+// Every pool has its own graph, in which signals are identified by their name.
+// Signals which do not belong to a pool share a graph, in which they are identified by themselves.
+type forwardGraph struct {
+	mu sync.Mutex
+	// The signal each forwarding receiver forwards to, by the signal it is connected to and its ID.
+	links map[any]map[uint64]any
+}
+
+// Initialize a new, empty graph of links between signals.
+func newForwardGraph() *forwardGraph {
+	return &forwardGraph{links: make(map[any]map[uint64]any)}
+}
+
+// Graph of the links between signals which do not belong to a pool.
+var forwards = newForwardGraph()
+
+// Add the link of the receiver with the given ID from src to dst,
+// unless dst (indirectly) forwards to src already.
+func (g *forwardGraph) link(id uint64, src, dst any) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.reachable(dst, src, make(map[any]struct{})) {
+		return false
+	}
+	if g.links[src] == nil {
+		g.links[src] = make(map[uint64]any)
+	}
+	g.links[src][id] = dst
+	return true
+}
+
+// Remove the link of the receiver with the given ID from src.
+func (g *forwardGraph) unlink(id uint64, src any) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if links, ok := g.links[src]; ok {
+		delete(links, id)
+		if len(links) == 0 {
+			delete(g.links, src)
+		}
+	}
+}
+
+// Remove all links from and to the signal.
+func (g *forwardGraph) remove(node any) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.links, node)
+	for src, links := range g.links {
+		for id, dst := range links {
+			if dst == node {
+				delete(links, id)
+			}
+		}
+		if len(links) == 0 {
+			delete(g.links, src)
+		}
+	}
+}
+
+// Check if to can be reached from from by following the links.
+//
+// The graph must be locked when calling this.
+func (g *forwardGraph) reachable(from, to any, visited map[any]struct{}) bool {
+	if from == to {
+		return true
+	}
+	if _, ok := visited[from]; ok {
+		return false
+	}
+	visited[from] = struct{}{}
+	for _, next := range g.links[from] {
+		if g.reachable(next, to, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// Return the graph of links between signals the signal belongs to, and the signal's node in it.
+func (s *signal[T]) forwardNode() (*forwardGraph, any) {
+	if s.forwards == nil {
+		return forwards, s
+	}
+	return s.forwards, s.name
+}
+
+// Remove the links from and to the signal, once it is deleted from its pool.
+func (s *signal[T]) unlinkForwards() {
+	var graph, node = s.forwardNode()
+	graph.remove(node)
+}
+
+// Receiver which forwards values from one signal to another,
+// the link between the signals is removed once it is disconnected.
+type forwardReceiver[T any] struct {
+	*receiver[T]
+	graph  *forwardGraph
+	src    any
+	linked atomic.Bool
+}
+
+// Sets the signal on the receiver instance for later use.
+func (r *forwardReceiver[T]) Signal(signal ...Signal[T]) Signal[T] {
+	if len(signal) > 0 && signal[0] == nil && r.linked.CompareAndSwap(true, false) {
+		r.graph.unlink(r.ID(), r.src)
+	}
+	return r.receiver.Signal(signal...)
+}
+
+// Options for forwarding values from one signal to another with ForwardTo.
+type ForwardOptions[T any] struct {
+	// Only forward values for which the filter returns true.
+	// If nil, all values are forwarded.
+	Filter func(T) bool
+	// Transform values before they are forwarded.
+	// If nil, values are forwarded as they are.
+	Transform func(T) T
+	// Do not return errors of the destination signal to the source signal,
+	// the errors are passed to the error hook of the source signal instead.
+	SwallowErrors bool
+}
+
+// Forward values sent on the signal to another signal.
+//
+// Returns the receiver which forwards the values,
+// disconnect it to stop forwarding.
+//
+// ErrForwardCycle is returned if dst already forwards to the signal,
+// directly or through other signals. Cycles are detected between the signals
+// of the same pool, and between signals which do not belong to a pool.
+func (s *signal[T]) ForwardTo(dst Signal[T], opts ForwardOptions[T]) (Receiver[T], error) {
+	var graph, src = s.forwardNode()
+	var to any = dst
+	if dst, ok := dst.(*signal[T]); ok {
+		if dstGraph, node := dst.forwardNode(); dstGraph == graph {
+			to = node
+		}
+	}
+
+	var r = &forwardReceiver[T]{graph: graph, src: src}
+	r.receiver = NewRecv(func(src Signal[T], value T) error {
+		if opts.Filter != nil && !opts.Filter(value) {
+			return nil
+		}
+		if opts.Transform != nil {
+			value = opts.Transform(value)
+		}
+
+		// The destination might have been replaced by Reconfigure.
+		var err = replacement(dst).Send(value)
+		if err == nil {
+			return nil
+		}
+
+		err = Error{Val: fmt.Sprintf("error forwarding to signal %q", dst.Name()), Err: err}
+		if opts.SwallowErrors {
+			s.handleError(err)
+			return nil
+		}
+		return err
+	})
+
+	if !graph.link(r.ID(), src, to) {
+		return nil, Error{
+			Val: fmt.Sprintf("forwarding from signal %q to %q would create a cycle", s.name, dst.Name()),
+			Err: ErrForwardCycle,
+		}
+	}
+	r.linked.Store(true)
+
+	if err := s.Connect(r); err != nil {
+		r.Signal(nil)
+		return nil, err
+	}
+	return r, nil
+}
+
+// Initialize a new receiver which forwards values to a signal in another pool.
+//
+// Each value received is sent to the signal with the given name in the target pool,
+// the signal is created if it does not exist.
+//
+// Errors returned by the target's receivers are returned to the signal.
+func NewPoolForwardRecv[T any](target *Pool[T], name string) Receiver[T] {
+	return NewRecv(func(s Signal[T], value T) error {
+		if err := target.CreateOrSend(name, value); err != nil {
+			return Error{Val: fmt.Sprintf("error forwarding to signal %q", name), Err: err}
+		}
+		return nil
+	})
+}
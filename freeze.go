@@ -0,0 +1,27 @@
+package signals
+
+// Freeze the receivers of the signal.
+//
+// After freezing, sending a value reads the receivers without locking the signal.
+// Connecting or disconnecting receivers will return ErrFrozen.
+//
+// Clearing the signal removes all receivers and unfreezes it.
+//
+// Returns an error if the receivers could not be ordered.
+func (s *signal[T]) Freeze() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var receivers, err = s.ordered()
+	if err != nil {
+		return err
+	}
+
+	s.frozen.Store(&receivers)
+	return nil
+}
+
+// Check if the receivers of the signal are frozen.
+func (s *signal[T]) isFrozen() bool {
+	return s.frozen.Load() != nil
+}
@@ -0,0 +1,79 @@
+package signals
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Receiver which records when it last received a value.
+type heartbeatReceiver[T any] struct {
+	*receiver[T]
+	lastActive atomic.Int64
+}
+
+// Initialize a new receiver which records when it last received a value.
+//
+// The receiver implements the Heartbeat interface,
+// use Pool.Stale to find receivers which have not been active recently.
+//
+// Options can be provided to configure the receiver,
+// WithClock sets the clock used to record when the receiver was last active.
+func NewHeartbeatRecv[T any](cb func(Signal[T], T) error, opts ...RecvOption) Receiver[T] {
+	return &heartbeatReceiver[T]{receiver: NewRecv(cb, opts...)}
+}
+
+// Receives the signal and value from the signal.
+func (r *heartbeatReceiver[T]) Receive(s Signal[T], value T) error {
+	r.lastActive.Store(r.opts.clock.Now().UnixNano())
+	return r.receiver.Receive(s, value)
+}
+
+// Return the time the receiver last received a value,
+// or the zero time if it has not received any values.
+func (r *heartbeatReceiver[T]) LastActive() time.Time {
+	var nanos = r.lastActive.Load()
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+// Return the IDs of receivers which have not been active within the threshold.
+//
+// Only receivers implementing the Heartbeat interface are checked.
+// A receiver which has not received any values yet is stale,
+// if it was connected longer ago than the threshold.
+//
+// Each receiver is checked against the clock it was created with, see WithClock.
+// Receivers without a clock are checked against the real clock.
+func (m *Pool[T]) Stale(threshold time.Duration) []uint64 {
+	var stale = make([]uint64, 0)
+	for _, s := range m.snapshot() {
+		var s, ok = s.(*signal[T])
+		if !ok {
+			continue
+		}
+
+		var receivers, _ = s.snapshot()
+		for _, receiver := range receivers {
+			var heartbeat, ok = unwrapAs[Heartbeat](receiver)
+			if !ok {
+				continue
+			}
+
+			var active = heartbeat.LastActive()
+			if timer, ok := unwrapAs[ConnectionTimer](receiver); ok && active.IsZero() {
+				active = timer.ConnectedAt()
+			}
+
+			var clock = RealClock
+			if r, ok := unwrapAs[interface{ clock() Clock }](receiver); ok {
+				clock = r.clock()
+			}
+			if clock.Now().Sub(active) > threshold {
+				stale = append(stale, receiver.ID())
+			}
+		}
+	}
+	return stale
+}
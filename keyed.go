@@ -0,0 +1,66 @@
+package signals
+
+import "sync"
+
+// Per-key queues of values waiting to be sent.
+//
+// Each key which has pending values has a single goroutine
+// working through its queue, so values sharing a key are
+// sent in order, while values with different keys are sent concurrently.
+type keyedQueues[T any] struct {
+	mu     sync.Mutex
+	queues map[string][]keyedValue[T]
+}
+
+// A value waiting to be sent, with the channel to report the result on.
+type keyedValue[T any] struct {
+	value   T
+	errChan chan error
+}
+
+// Send a signal to all receivers asynchronously, ordered by key.
+//
+// Values sent with the same key are delivered one after another, in the order they were sent.
+//
+// Values sent with different keys are delivered concurrently.
+//
+// Returns a channel which will receive the result of the send, and is then closed.
+func (s *signal[T]) SendWithKey(key string, value T) chan error {
+	var errChan = make(chan error, 1)
+
+	s.keyed.mu.Lock()
+	defer s.keyed.mu.Unlock()
+
+	if s.keyed.queues == nil {
+		s.keyed.queues = make(map[string][]keyedValue[T])
+	}
+
+	var queue, running = s.keyed.queues[key]
+	s.keyed.queues[key] = append(queue, keyedValue[T]{value: value, errChan: errChan})
+
+	// Only start a worker if there is none for this key yet.
+	if !running {
+		go s.drainKey(key)
+	}
+
+	return errChan
+}
+
+// Send all values queued for the key, until the queue is empty.
+func (s *signal[T]) drainKey(key string) {
+	for {
+		s.keyed.mu.Lock()
+		var queue = s.keyed.queues[key]
+		if len(queue) == 0 {
+			delete(s.keyed.queues, key)
+			s.keyed.mu.Unlock()
+			return
+		}
+		var next = queue[0]
+		s.keyed.queues[key] = queue[1:]
+		s.keyed.mu.Unlock()
+
+		next.errChan <- s.send(next.value).Err()
+		close(next.errChan)
+	}
+}
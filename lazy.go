@@ -0,0 +1,100 @@
+package signals
+
+import (
+	"context"
+	"sync"
+)
+
+// Receiver which constructs the receiver it wraps on the first send.
+type lazyReceiver[T any] struct {
+	*receiver[T]
+	factory func() (Receiver[T], error)
+	mu      sync.Mutex
+	built   Receiver[T]
+}
+
+// Connect a receiver which is constructed on the first send.
+//
+// The factory is called once, when a value is first sent to the receiver.
+// The constructed receiver is reused for all values sent afterwards.
+//
+// If the factory returns an error, the error is returned to the signal,
+// and the factory is called again on the next send.
+//
+// The connected receiver wraps the constructed receiver,
+// disconnecting either of them disconnects the wrapper from the signal.
+// Once constructed, optional interfaces of the constructed receiver such as
+// ContextReceiver, Flusher and io.Closer are used through the wrapper.
+func (s *signal[T]) ConnectLazy(factory func() (Receiver[T], error)) (Receiver[T], error) {
+	var r = &lazyReceiver[T]{factory: factory}
+	r.receiver = NewRecv(r.receive)
+	return r, s.Connect(r)
+}
+
+// Return the constructed receiver, constructing it if this has not been done yet.
+func (r *lazyReceiver[T]) build(signal Signal[T]) (Receiver[T], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.built != nil {
+		return r.built, nil
+	}
+
+	var built, err = r.factory()
+	if err != nil {
+		return nil, err
+	}
+	if built == nil {
+		return nil, wrap(ErrNilReceiver)
+	}
+	built.Signal(&lazySignal[T]{Signal: signal, lazy: r, built: built})
+	r.built = built
+	return built, nil
+}
+
+// Receives the signal and value from the signal, and passes them to the constructed receiver.
+func (r *lazyReceiver[T]) receive(s Signal[T], value T) error {
+	var built, err = r.build(s)
+	if err != nil {
+		return err
+	}
+	return built.Receive(s, value)
+}
+
+// Receives the context, signal and value from the signal, and passes them to the constructed receiver.
+//
+// The context is passed on if the constructed receiver implements ContextReceiver.
+func (r *lazyReceiver[T]) ReceiveContext(ctx context.Context, s Signal[T], value T) error {
+	var built, err = r.build(s)
+	if err != nil {
+		return err
+	}
+	return receiveContext(ctx, built, s, value)
+}
+
+// Return the constructed receiver, nil if it has not been constructed yet.
+func (r *lazyReceiver[T]) unwrap() Receiver[T] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.built
+}
+
+// Signal bound to a receiver constructed by ConnectLazy.
+//
+// The signal holds the lazy receiver instead of the constructed receiver,
+// disconnecting the constructed receiver disconnects the lazy receiver.
+type lazySignal[T any] struct {
+	Signal[T]
+	lazy, built Receiver[T]
+}
+
+// Disconnect a list of receivers from the signal.
+func (s *lazySignal[T]) Disconnect(other ...Receiver[T]) {
+	var receivers = make([]Receiver[T], len(other))
+	for i, receiver := range other {
+		if receiver != nil && receiver.ID() == s.built.ID() {
+			receiver = s.lazy
+		}
+		receivers[i] = receiver
+	}
+	s.Signal.Disconnect(receivers...)
+}
@@ -0,0 +1,35 @@
+package signals
+
+// Set a metadata value on the signal.
+//
+// Metadata can be used to organize signals, e.g. by team or criticality,
+// and to find signals inside of a pool with FindByMeta.
+func (s *signal[T]) SetMeta(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.meta == nil {
+		s.meta = make(map[string]string)
+	}
+	s.meta[key] = value
+}
+
+// Return a metadata value of the signal.
+//
+// Returns false if the key has not been set.
+func (s *signal[T]) Meta(key string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var value, ok = s.meta[key]
+	return value, ok
+}
+
+// Return the signals inside of the pool with the given metadata value.
+func (m *Pool[T]) FindByMeta(key, value string) []Signal[T] {
+	var signals = make([]Signal[T], 0)
+	for _, signal := range m.snapshot() {
+		if v, ok := signal.Meta(key); ok && v == value {
+			signals = append(signals, signal)
+		}
+	}
+	return signals
+}
@@ -0,0 +1,32 @@
+package signals
+
+// Add middleware which is called around every receiver.
+//
+// Each middleware receives the next function in the chain, and returns
+// the function called in its place. The middleware is applied in the order
+// it was added, the first middleware is the outermost.
+//
+// Errors returned by the middleware are collected like errors returned by the receiver.
+func (s *signal[T]) Use(middleware ...func(next func(Signal[T], T) error) func(Signal[T], T) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var chain []func(next func(Signal[T], T) error) func(Signal[T], T) error
+	if current := s.middleware.Load(); current != nil {
+		chain = append(chain, *current...)
+	}
+	chain = append(chain, middleware...)
+	s.middleware.Store(&chain)
+}
+
+// Call the receive function with the value, wrapped in the middleware of the signal.
+func (s *signal[T]) around(receive func(Signal[T], T) error, value T) error {
+	var chain = s.middleware.Load()
+	if chain == nil {
+		return receive(s, value)
+	}
+	for i := len(*chain) - 1; i >= 0; i-- {
+		receive = (*chain)[i](receive)
+	}
+	return receive(s, value)
+}
@@ -0,0 +1,40 @@
+package signals
+
+// Mirror every value sent on the signal to another signal.
+//
+// Values are sent to the secondary signal asynchronously,
+// so that a slow secondary signal does not slow down the send.
+// Errors returned by the secondary signal are passed to the error hook.
+//
+// Values sent by the sends which call the receivers one after another are mirrored:
+// Send, SendDetailed, SendContext, SendWithTimeout, SendOrQueue, SendRecorded,
+// SendRetry, SendWithKey, EmitPartial and Collect. SendRetry mirrors the value once,
+// not on every attempt.
+//
+// Values sent with SendAsync, SendAsyncN, SendAsyncResults, SendAsyncWait,
+// SendWeighted or SendTx are not mirrored.
+func (s *signal[T]) Mirror(secondary Signal[T]) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var mirrors []Signal[T]
+	if current := s.mirrors.Load(); current != nil {
+		mirrors = append(mirrors, *current...)
+	}
+	mirrors = append(mirrors, secondary)
+	s.mirrors.Store(&mirrors)
+}
+
+// Send the value to the mirrors of the signal in the background.
+func (s *signal[T]) mirror(value T) {
+	var mirrors = s.mirrors.Load()
+	if mirrors == nil {
+		return
+	}
+	for _, secondary := range *mirrors {
+		// The secondary signal might have been replaced by Reconfigure.
+		go func(secondary Signal[T]) {
+			s.handleError(secondary.Send(value))
+		}(replacement(secondary))
+	}
+}
@@ -0,0 +1,101 @@
+package signals
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Report signals created with the same name as an existing signal.
+//
+// When set, the function is called with the name of each signal created while
+// another signal with the same name exists. Only signals created while it is set
+// are taken into account. Fetching an existing signal from a pool does not create
+// a signal, and is never reported.
+//
+// Signals created with New are compared with all other signals. Signals created
+// by a pool are only compared with signals created with New, different pools
+// can have signals with the same name.
+//
+// Names are released when the signal is deleted from its pool, shut down,
+// or garbage collected.
+var OnDuplicateName func(name string)
+
+var names = struct {
+	mu sync.Mutex
+	// Names of signals created with New.
+	standalone map[string]int
+	// Names of signals created by a pool.
+	pooled map[string]int
+}{
+	standalone: make(map[string]int),
+	pooled:     make(map[string]int),
+}
+
+// Registration of the name of a signal.
+//
+// Only the signal refers to its registration, so that the name
+// can be released once the signal is garbage collected.
+type registeredName struct {
+	name     string
+	pooled   bool
+	released atomic.Bool
+}
+
+// Release the name, releasing it more than once has no effect.
+func (r *registeredName) release() {
+	if !r.released.CompareAndSwap(false, true) {
+		return
+	}
+
+	names.mu.Lock()
+	defer names.mu.Unlock()
+	var counts = names.standalone
+	if r.pooled {
+		counts = names.pooled
+	}
+	if counts[r.name] <= 1 {
+		delete(counts, r.name)
+		return
+	}
+	counts[r.name]--
+}
+
+// Register the name of a newly created signal, if OnDuplicateName is set.
+//
+// Pooled is set for signals created by a pool.
+// Calls OnDuplicateName if a signal with the same name already exists.
+func (s *signal[T]) registerName(pooled bool) {
+	var hook = OnDuplicateName
+	if hook == nil {
+		return
+	}
+
+	var registered = &registeredName{name: s.name, pooled: pooled}
+	if !s.registered.CompareAndSwap(nil, registered) {
+		return
+	}
+	runtime.SetFinalizer(registered, (*registeredName).release)
+
+	names.mu.Lock()
+	var duplicate = names.standalone[s.name] > 0 || !pooled && names.pooled[s.name] > 0
+	if pooled {
+		names.pooled[s.name]++
+	} else {
+		names.standalone[s.name]++
+	}
+	names.mu.Unlock()
+
+	if duplicate {
+		hook(s.name)
+	}
+}
+
+// Release the name of a signal which was deleted or shut down.
+//
+// Releasing the name of a signal more than once has no effect.
+func (s *signal[T]) releaseName() {
+	if registered := s.registered.Swap(nil); registered != nil {
+		registered.release()
+	}
+}
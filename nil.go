@@ -0,0 +1,53 @@
+package signals
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Check if the dynamic type of the value matches the locked type of the signal.
+//
+// The type of the first value checked becomes the locked type.
+func (s *signal[T]) checkType(value T) error {
+	var typ = reflect.TypeOf(any(value))
+	if typ == nil {
+		return nil
+	}
+
+	// The type is stored atomically, so that sends on frozen signals stay lock-free.
+	var locked = s.lockedType.Load()
+	if locked == nil {
+		if s.lockedType.CompareAndSwap(nil, &typ) {
+			return nil
+		}
+		// Another send locked the type first.
+		locked = s.lockedType.Load()
+	}
+
+	if *locked != typ {
+		return Error{
+			Val: fmt.Sprintf("signal %q only accepts values of type %s, got %s", s.name, *locked, typ),
+			Err: ErrTypeMismatch,
+		}
+	}
+	return nil
+}
+
+// Check if a value is nil.
+//
+// This detects both untyped nils, and typed nils such as a nil pointer
+// stored in an interface, (*Foo)(nil), which do not compare equal to nil.
+func IsNilValue[T any](v T) bool {
+	var value any = v
+	if value == nil {
+		return true
+	}
+
+	var rv = reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func,
+		reflect.Chan, reflect.Interface, reflect.UnsafePointer:
+		return rv.IsNil()
+	}
+	return false
+}
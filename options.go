@@ -0,0 +1,165 @@
+package signals
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// Option for configuring a signal.
+//
+// Options can be passed to New() when creating a single signal,
+// or to NewPool() to apply them to every signal created by the pool.
+type Option[T any] func(*signal[T])
+
+// Set the maximum size of a value in bytes.
+//
+// Values larger than this will be rejected by Send before any receivers are called.
+//
+// A size of 0 or less means there is no limit.
+func WithMaxValueSize[T any](size int) Option[T] {
+	return func(s *signal[T]) {
+		s.maxValueSize = size
+	}
+}
+
+// Set the function which is called with errors that cannot be returned to the caller.
+//
+// This is the case for errors which occur after a send has returned,
+// for example from receivers which were moved to the background.
+func WithErrorHook[T any](hook func(Signal[T], error)) Option[T] {
+	return func(s *signal[T]) {
+		s.errorHook = hook
+	}
+}
+
+// Set the function which extracts values from the context passed to SendContext.
+//
+// The extracted values are attached to errors returned by SendContext,
+// for example to correlate a failed send with the request which started it.
+func WithContextExtractor[T any](extract func(ctx context.Context) map[string]string) Option[T] {
+	return func(s *signal[T]) {
+		s.contextExtractor = extract
+	}
+}
+
+// Set the timeout used by Send.
+//
+// Send will behave like SendWithTimeout with the given timeout,
+// calling SendWithTimeout directly overrides the default timeout.
+//
+// A timeout of 0 or less means there is no timeout.
+func WithDefaultTimeout[T any](timeout time.Duration) Option[T] {
+	return func(s *signal[T]) {
+		s.defaultTimeout = timeout
+	}
+}
+
+// Shuffle the order of the receivers before each send.
+//
+// This distributes the position of each receiver evenly over many sends,
+// so that no receiver is always called first or last.
+//
+// Receivers are still called after the receivers they depend on,
+// and an order function set with SetOrderFunc is applied after shuffling.
+//
+// The order of a frozen signal is shuffled once, when it is frozen.
+func ShuffleReceivers[T any]() Option[T] {
+	return func(s *signal[T]) {
+		s.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+}
+
+// Lock the dynamic type of the values sent on the signal.
+//
+// The dynamic type of the first value sent is remembered,
+// values of a different type are rejected with ErrTypeMismatch.
+//
+// This is useful for signals of an interface type, e.g. a Pool[any].
+// Nil values have no dynamic type, and are never rejected.
+func WithTypeLock[T any]() Option[T] {
+	return func(s *signal[T]) {
+		s.typeLock = true
+	}
+}
+
+// Pre-allocate room for the given amount of receivers.
+//
+// This reduces allocations when connecting many receivers to the signal.
+func WithCapacity[T any](n int) Option[T] {
+	return func(s *signal[T]) {
+		if n > cap(s.receivers) {
+			s.receivers = make([]Receiver[T], 0, n)
+		}
+	}
+}
+
+// Recover from panics in receivers.
+//
+// A receiver which panics returns a PanicError wrapping ErrReceiverPanic,
+// and the remaining receivers are still called.
+func WithRecover[T any]() Option[T] {
+	return func(s *signal[T]) {
+		s.recoverPanics.Store(true)
+	}
+}
+
+// Stop sending a value after the first receiver returns an error.
+//
+// This is the same as setting the error strategy to StopOnFirstError.
+func StopOnError[T any]() Option[T] {
+	return func(s *signal[T]) {
+		s.errorStrategy.Store(int32(StopOnFirstError))
+	}
+}
+
+// Make the signal sticky.
+//
+// A sticky signal retains the last value sent to it,
+// receivers connected afterwards immediately receive the retained value.
+func Sticky[T any]() Option[T] {
+	return func(s *signal[T]) {
+		s.sticky = true
+	}
+}
+
+// Reject nil values, both untyped and typed nils.
+//
+// Sending a nil value will return ErrNilValue without calling any receivers.
+//
+// See IsNilValue for which values are considered nil.
+func RejectNil[T any]() Option[T] {
+	return func(s *signal[T]) {
+		s.rejectNil = true
+	}
+}
+
+// Set the function used to measure the size of a value.
+//
+// This is only used when a maximum value size has been set.
+//
+// Defaults to DefaultSizer.
+func WithSizer[T any](sizer func(T) int) Option[T] {
+	return func(s *signal[T]) {
+		s.sizer = sizer
+	}
+}
+
+// Return ErrNoReceivers when the signal is sent without any receivers connected.
+//
+// By default, such a send succeeds without doing anything.
+func RequireReceivers[T any]() Option[T] {
+	return func(s *signal[T]) {
+		s.requireReceivers = true
+	}
+}
+
+// Allow the signal to be sent without any receivers connected.
+//
+// This is the default, it can be used to override
+// RequireReceivers for a single signal of a pool.
+func AllowNoReceivers[T any]() Option[T] {
+	return func(s *signal[T]) {
+		s.requireReceivers = false
+	}
+}
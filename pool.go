@@ -1,6 +1,9 @@
 package signals
 
 import (
+	"fmt"
+	"runtime"
+	"strings"
 	"sync"
 )
 
@@ -10,14 +13,50 @@ import (
 //
 // Can also be used to send signals to receivers.
 type Pool[T any] struct {
-	mu sync.RWMutex
-	m  map[string]Signal[T]
+	signals store[T]
+	opts    []Option[T]
+
+	// Prefix for the names of signals in a namespace, and the pool it belongs to.
+	prefix string
+	root   *Pool[T]
+
+	// Mutex for the configuration of the pool.
+	mu              sync.RWMutex
+	transformer     func(name string, value T) (T, error)
+	strict          bool
+	changeHooks     []func(ReceiverChangeEvent)
+	defaultReceiver func(name string) Receiver[T]
+	waiters         map[chan struct{}]struct{}
+
+	// Links created by ForwardTo between the signals of the pool.
+	forwards *forwardGraph
 }
 
 // Return a new pool of signals.
-func NewPool[T any]() *Pool[T] {
+//
+// The options will be applied to every signal created by the pool.
+func NewPool[T any](opts ...Option[T]) *Pool[T] {
 	return &Pool[T]{
-		m: make(map[string]Signal[T]),
+		signals:  newMapStore[T](),
+		opts:     opts,
+		forwards: newForwardGraph(),
+	}
+}
+
+// Return a new pool of signals, backed by a sync.Map.
+//
+// This pool has the same API as a pool created with NewPool(),
+// but reduces lock contention for read-heavy workloads,
+// where signals are mostly fetched and rarely created or deleted.
+//
+// No lock is held while ranging over the signals in this pool.
+//
+// The options will be applied to every signal created by the pool.
+func NewSyncPool[T any](opts ...Option[T]) *Pool[T] {
+	return &Pool[T]{
+		signals:  &syncMapStore[T]{},
+		opts:     opts,
+		forwards: newForwardGraph(),
 	}
 }
 
@@ -25,36 +64,353 @@ func NewPool[T any]() *Pool[T] {
 // Use .Get() to fetch a signal from the pool.
 // This will create one if it does not exist.
 func (m *Pool[T]) load(signalName string) (value Signal[T], ok bool) {
-	m.mu.RLock()
-	value, ok = m.m[signalName]
-	m.mu.RUnlock()
-	return
+	return m.signals.load(m.prefix + signalName)
+}
+
+// Load a signal from the pool, or create it if it does not exist.
+//
+// If two goroutines create the same signal at once, both will receive the same signal.
+func (m *Pool[T]) loadOrCreate(signalName string) Signal[T] {
+	signalName = m.prefix + signalName
+	if signal, ok := m.signals.load(signalName); ok {
+		return signal
+	}
+	var s = m.newSignal(signalName)
+	var signal, loaded = m.signals.loadOrStore(signalName, s)
+	if !loaded {
+		s.registerName(true)
+		m.created(signal)
+	}
+	return signal
 }
 
-// Store a signal in the pool.
-// Use .Get() to create a new signal if it does not exist.
-func (m *Pool[T]) store(signalName string, value Signal[T]) {
-	m.mu.Lock()
-	m.m[signalName] = value
-	m.mu.Unlock()
+// Initialize a new signal for the pool.
+//
+// The pool's options are applied to the new signal first, followed by the provided options.
+func (m *Pool[T]) newSignal(name string, opts ...Option[T]) *signal[T] {
+	var allOpts = make([]Option[T], 0, len(m.opts)+len(opts))
+	allOpts = append(allOpts, m.opts...)
+	allOpts = append(allOpts, opts...)
+	var s = newSignal(name, allOpts...)
+	s.changeHook = m.config().notifyReceiverChange
+	s.forwards = m.config().forwards
+	return s
+}
+
+// Replace a signal inside of the pool with a newly configured signal.
+//
+// The pool's options are applied to the new signal first, followed by the provided options.
+//
+// Receivers connected to the existing signal are moved to the new signal, together with
+// everything which was configured on it after it was created: interceptors, the validator,
+// middleware, metadata, mirrors, the fallback, the order function and the template.
+// Receivers connected with ConnectCtx are still disconnected once their context is done.
+// Panic recovery and the error strategy are kept, unless the options enable them.
+//
+// Sends on the existing signal are not waited for, sends which are in progress
+// finish with the receivers they started with. References to the old signal
+// can no longer be used, sending on it or connecting to it returns ErrSignalDeleted,
+// and disconnecting from it or clearing it is a no-op.
+// Signals which forward or mirror to the old signal send to the new signal instead.
+//
+// If the signal does not exist, it will be created.
+func (m *Pool[T]) Reconfigure(name string, opts ...Option[T]) Signal[T] {
+	name = m.prefix + name
+	for {
+		var next = m.newSignal(name, opts...)
+		var old, loaded = m.signals.loadOrStore(name, next)
+		if !loaded {
+			next.registerName(true)
+			m.created(next)
+			return next
+		}
+
+		var s, ok = old.(*signal[T])
+		if !ok {
+			m.signals.store(name, next)
+			next.registerName(true)
+			return next
+		}
+
+		// Lock the old signal so that no receivers can be connected while we are migrating.
+		// If another call replaced or deleted the signal while we were waiting for the lock, start over.
+		s.mu.Lock()
+		if s.deleted.Load() {
+			s.mu.Unlock()
+			runtime.Gosched()
+			continue
+		}
+		var watchers = next.migrate(s)
+		next.registered.Store(s.registered.Swap(nil))
+		s.replacement.Store(next)
+		s.deleted.Store(true)
+		s.mu.Unlock()
+
+		// The pool is locked while ranging over its signals, and ranging may lock the
+		// signals. The old signal is swapped for the new one after unlocking it.
+		// If the signal was deleted in the meantime, the new signal is deleted with it.
+		if !m.signals.compareAndSwap(name, old, next) {
+			next.deleted.Store(true)
+			next.releaseName()
+		}
+
+		for _, watch := range watchers {
+			go watch()
+		}
+		return next
+	}
+}
+
+// Move the receivers and the state which is not set by options from another signal.
+//
+// The receivers are moved, not connected, so this is not reported.
+// The other signal is left without receivers.
+//
+// The other signal must be locked, and this signal must not be in use yet.
+// Returns the watchers of receivers connected with ConnectCtx,
+// they are meant to be run in their own goroutines.
+func (s *signal[T]) migrate(from *signal[T]) []func() {
+	for _, receiver := range from.receivers {
+		receiver.Signal(s)
+		s.receivers = append(s.receivers, receiver)
+	}
+	s.dependents = from.dependents
+	s.asyncs = from.asyncs
+	s.prioritized = from.prioritized
+	s.orderFunc = from.orderFunc
+
+	s.config.Store(from.config.Load())
+	s.template = from.template
+	s.mirrors.Store(from.mirrors.Load())
+	s.middleware.Store(from.middleware.Load())
+	if len(from.meta) > 0 {
+		s.meta = make(map[string]string, len(from.meta))
+		for k, v := range from.meta {
+			s.meta[k] = v
+		}
+	}
+	if s.sticky {
+		s.retained.Store(from.retained.Load())
+	}
+	if s.typeLock {
+		s.lockedType.Store(from.lockedType.Load())
+	}
+	if !s.recoverPanics.Load() {
+		s.recoverPanics.Store(from.recoverPanics.Load())
+	}
+	if ErrorStrategy(s.errorStrategy.Load()) == CollectErrors {
+		s.errorStrategy.Store(from.errorStrategy.Load())
+	}
+
+	var watchers = make([]func(), 0, len(from.watchers))
+	for _, receiver := range from.receivers {
+		if w, ok := from.watchers[receiver.ID()]; ok {
+			from.unwatch(receiver)
+			watchers = append(watchers, s.watch(w.ctx, receiver))
+		}
+	}
+
+	if from.isFrozen() {
+		if receivers, err := s.ordered(); err == nil {
+			s.frozen.Store(&receivers)
+		}
+	}
+
+	// The receivers now belong to this signal, references
+	// to the other signal must not be able to disconnect them.
+	from.receivers = make([]Receiver[T], 0)
+	from.dependents = 0
+	from.asyncs = 0
+	from.order = nil
+	return watchers
+}
+
+// Close the pool.
+//
+// All receivers implementing the Flusher interface are flushed,
+// after which every signal is cleared and removed from the pool.
+//
+// Returns an error, if flushing any of the receivers failed.
+func (m *Pool[T]) Close() error {
+	var errs = make([]error, 0)
+	for _, s := range m.snapshot() {
+		if s, ok := s.(*signal[T]); ok {
+			errs = append(errs, s.flush()...)
+		}
+	}
+
+	for _, s := range m.snapshot() {
+		s.Clear()
+		m.remove(s.Name())
+	}
+
+	if len(errs) > 0 {
+		return e(fmt.Sprintf("error flushing %d receivers", len(errs)), errs...)
+	}
+	return nil
 }
 
 // Delete a signal from the pool.
+//
+// References to the deleted signal can no longer be used,
+// connecting to or sending on it returns an error wrapping ErrSignalDeleted,
+// and disconnecting from or clearing it is a no-op.
 func (m *Pool[T]) Delete(signalName string) {
-	m.mu.Lock()
-	delete(m.m, signalName)
-	m.mu.Unlock()
+	m.remove(m.prefix + signalName)
+}
+
+// Remove the signal with the full name from the pool, and mark it as deleted.
+//
+// If the signal is replaced by Reconfigure while it is being removed,
+// the signal which replaced it is removed instead.
+func (m *Pool[T]) remove(name string) {
+	for {
+		var s, ok = m.signals.load(name)
+		if !ok {
+			return
+		}
+		if !m.signals.compareAndDelete(name, s) {
+			continue
+		}
+		if s, ok := s.(*signal[T]); ok {
+			s.deleted.Store(true)
+			s.releaseName()
+			s.unlinkForwards()
+		}
+		return
+	}
+}
+
+// Return the signal which replaced the signal in its pool, after any amount of reconfigurations.
+//
+// Returns the signal itself if it was not replaced.
+func replacement[T any](s Signal[T]) Signal[T] {
+	var sig, ok = s.(*signal[T])
+	if !ok {
+		return s
+	}
+	for {
+		var next = sig.replacement.Load()
+		if next == nil {
+			return sig
+		}
+		sig = next
+	}
+}
+
+// Return the error for using a signal after it was deleted from its pool.
+func (s *signal[T]) deletedError() error {
+	return Error{Val: fmt.Sprintf("signal %q was deleted from its pool", s.name), Err: ErrSignalDeleted}
 }
 
 // Range over signals inside of the pool.
+//
+// For a namespace, only the signals inside of the namespace are visited.
+//
+// The pool may be locked while the callback is called, the callback must not
+// create, delete or reconfigure signals in the pool. Use RangeSnapshot for this.
 func (m *Pool[T]) Range(f func(value Signal[T]) bool) {
-	m.mu.RLock()
-	for _, value := range m.m {
+	if m.prefix == "" {
+		m.signals.rangeSignals(f)
+		return
+	}
+	m.signals.rangeSignals(func(value Signal[T]) bool {
+		if !strings.HasPrefix(value.Name(), m.prefix) {
+			return true
+		}
+		return f(value)
+	})
+}
+
+// Return a namespace inside of the pool.
+//
+// The namespace shares its signals with the pool, but the names of
+// signals are prefixed with the prefix and a dot, e.g. "prefix.name".
+//
+// Sending globally, ranging over or closing a namespace only affects the signals inside of it.
+//
+// Namespaces can be nested, the configuration of the pool is shared with its namespaces.
+func (m *Pool[T]) Namespace(prefix string) *Pool[T] {
+	return &Pool[T]{
+		signals: m.signals,
+		opts:    m.opts,
+		prefix:  m.prefix + prefix + ".",
+		root:    m.config(),
+	}
+}
+
+// Return the pool which holds the configuration, this is the root pool for namespaces.
+func (m *Pool[T]) config() *Pool[T] {
+	if m.root != nil {
+		return m.root
+	}
+	return m
+}
+
+// Return the amount of signals inside of the pool.
+func (m *Pool[T]) Len() int {
+	return len(m.snapshot())
+}
+
+// Return the names of the signals inside of the pool, in no particular order.
+//
+// For a namespace, the names are returned without the namespace's prefix,
+// so that they can be passed to Get.
+func (m *Pool[T]) Names() []string {
+	var signals = m.snapshot()
+	var names = make([]string, 0, len(signals))
+	for _, value := range signals {
+		names = append(names, strings.TrimPrefix(value.Name(), m.prefix))
+	}
+	return names
+}
+
+// Range over a snapshot of the signals inside of the pool.
+//
+// The signals are copied while the pool is locked, the lock is released
+// before the callback is called. This means the callback is free to
+// create, delete or send to signals in the pool.
+//
+// Signals added or deleted during iteration will not be reflected in the snapshot.
+func (m *Pool[T]) RangeSnapshot(f func(value Signal[T]) bool) {
+	for _, value := range m.snapshot() {
 		if !f(value) {
 			break
 		}
 	}
-	m.mu.RUnlock()
+}
+
+// Copy the signals inside of the pool to a new slice.
+//
+// For a namespace, only the signals inside of the namespace are copied.
+func (m *Pool[T]) snapshot() []Signal[T] {
+	var signals = make([]Signal[T], 0)
+	m.Range(func(value Signal[T]) bool {
+		signals = append(signals, value)
+		return true
+	})
+	return signals
+}
+
+// Connect a receiver to every signal currently inside of the pool.
+//
+// Signals created after this call will not have the receiver connected.
+//
+// The receiver's signal will be set to the last signal it was connected to,
+// disconnecting it from every signal requires calling Disconnect on each signal.
+//
+// Returns an error, if connecting to any of the signals failed.
+func (m *Pool[T]) ConnectAll(r Receiver[T]) error {
+	var errs = make([]error, 0)
+	for _, signal := range m.snapshot() {
+		if err := signal.Connect(r); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return e(fmt.Sprintf("error connecting receiver to %d signals", len(errs)), errs...)
+	}
+	return nil
 }
 
 // Send a signal inside of the signal pool, from the signal with the given name
@@ -62,33 +418,217 @@ func (m *Pool[T]) Range(f func(value Signal[T]) bool) {
 func (m *Pool[T]) Send(name string, value T) error {
 	var signal, ok = m.load(name)
 	if !ok {
-		return e("signal not found")
+		return wrap(ErrSignalNotFound)
 	}
-	return signal.Send(value)
+	return m.send(signal, value)
 }
 
 // Send a signal globally, across all signals present in the pool.
 //
 // This will send a signal to ALL receivers inside of this pool.
+//
+// The value is sent to a snapshot of the signals, the pool is not locked
+// while the receivers are called. Receivers are free to create, delete
+// or send to signals in the pool.
+//
+// The value is sent to every signal, even if sending to a signal fails.
+// Returns the errors of all signals which failed.
+//
+// Signals without any receivers are skipped, they do not return ErrNoReceivers.
+// Errors of receivers which wrap ErrNoReceivers, e.g. when forwarding to
+// another signal without receivers, are still returned.
 func (m *Pool[T]) SendGlobal(value T) error {
-	var err error
-	m.Range(func(signal Signal[T]) bool {
-		err = signal.Send(value)
-		return err == nil
-	})
-	return err
+	var errs = make([]error, 0)
+	for _, signal := range m.snapshot() {
+		if !signal.HasReceivers() {
+			continue
+		}
+		if err := m.send(signal, value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return e(fmt.Sprintf("error sending signal to %d signals", len(errs)), errs...)
+	}
+	return nil
+}
+
+// Send a signal globally, across all signals present in the pool concurrently.
+//
+// Every signal is sent to in its own goroutine. A limit can optionally be provided,
+// to cap the amount of signals which are sent to at the same time.
+// A limit of 0 or less means there is no limit.
+//
+// Returns the errors of all signals which failed,
+// signals without any receivers are skipped like in SendGlobal.
+func (m *Pool[T]) SendGlobalConcurrent(value T, limit ...int) error {
+	var signals = m.snapshot()
+	var sem chan struct{}
+	if len(limit) > 0 && limit[0] > 0 {
+		sem = make(chan struct{}, limit[0])
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs = make([]error, 0)
+	)
+	for _, signal := range signals {
+		if !signal.HasReceivers() {
+			continue
+		}
+		if sem != nil {
+			sem <- struct{}{}
+		}
+		wg.Add(1)
+		go func(signal Signal[T]) {
+			defer wg.Done()
+			var err = m.send(signal, value)
+			if sem != nil {
+				<-sem
+			}
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(signal)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return e(fmt.Sprintf("error sending signal to %d signals", len(errs)), errs...)
+	}
+	return nil
 }
 
 // Create or send a signal inside of the signal pool.
 //
 // This will send a signal to the receivers, if the signal already exists.
+//
+// In strict mode, an error is returned if the signal has not been declared.
 func (m *Pool[T]) CreateOrSend(name string, value T) error {
-	var s, ok = m.load(name)
+	var signal, err = m.lookup(name)
+	if err != nil {
+		return err
+	}
+	return m.send(signal, value)
+}
+
+// Enable or disable strict mode for the pool.
+//
+// In strict mode, signals are not created on first use by Listen or CreateOrSend,
+// these return ErrSignalNotFound instead. Signals must be created with Declare.
+//
+// Strict mode is shared with the namespaces of the pool.
+func (m *Pool[T]) SetStrictMode(strict bool) {
+	var c = m.config()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.strict = strict
+}
+
+// Declare a signal inside of the pool.
+//
+// The pool's options are applied to the new signal first, followed by the provided options.
+//
+// If the signal already exists, the existing signal is returned and the options are ignored.
+func (m *Pool[T]) Declare(name string, opts ...Option[T]) Signal[T] {
+	name = m.prefix + name
+	if signal, ok := m.signals.load(name); ok {
+		return signal
+	}
+
+	var s = m.newSignal(name, opts...)
+	var signal, loaded = m.signals.loadOrStore(name, s)
+	if !loaded {
+		s.registerName(true)
+		m.created(signal)
+	}
+	return signal
+}
+
+// Load a signal from the pool, creating it if the pool is not in strict mode.
+func (m *Pool[T]) lookup(name string) (Signal[T], error) {
+	var c = m.config()
+	c.mu.RLock()
+	var strict = c.strict
+	c.mu.RUnlock()
+
+	if !strict {
+		return m.loadOrCreate(name), nil
+	}
+
+	var signal, ok = m.load(name)
 	if !ok {
-		s = &signal[T]{name: name, receivers: make([]Receiver[T], 0), mu: &sync.Mutex{}}
-		m.store(name, s)
+		return nil, Error{Val: fmt.Sprintf("signal %q has not been declared", m.prefix+name), Err: ErrSignalNotFound}
+	}
+	return signal, nil
+}
+
+// Set the factory for a receiver which is connected to every signal created by the pool.
+//
+// The factory is called with the name of the signal each time a signal is created,
+// signals which already exist are not affected. If the factory returns nil,
+// no receiver is connected. Passing nil removes the factory.
+//
+// This can be used to attach a standard logging or metrics receiver to every signal.
+func (m *Pool[T]) SetDefaultReceiver(factory func(name string) Receiver[T]) {
+	var c = m.config()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.defaultReceiver = factory
+}
+
+// Connect the default receiver to a newly created signal.
+func (m *Pool[T]) created(signal Signal[T]) {
+	var c = m.config()
+	c.mu.RLock()
+	var factory = c.defaultReceiver
+	c.mu.RUnlock()
+
+	if factory == nil {
+		return
+	}
+	if receiver := factory(signal.Name()); receiver != nil {
+		signal.Connect(receiver)
+	}
+}
+
+// Set the function which transforms every value sent through the pool.
+//
+// The transformer is called with the name of the signal before the value is
+// sent by Send, SendGlobal or CreateOrSend. The returned value is sent instead.
+//
+// If the transformer returns an error, the value is not sent and the error is returned.
+//
+// Values sent directly on a signal are not transformed.
+//
+// Passing nil removes the transformer.
+func (m *Pool[T]) SetValueTransformer(transformer func(name string, value T) (T, error)) {
+	var c = m.config()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.transformer = transformer
+}
+
+// Transform the value and send it on the signal.
+func (m *Pool[T]) send(signal Signal[T], value T) error {
+	var c = m.config()
+	c.mu.RLock()
+	var transformer = c.transformer
+	c.mu.RUnlock()
+
+	if transformer != nil {
+		var err error
+		value, err = transformer(signal.Name(), value)
+		if err != nil {
+			return Error{Val: fmt.Sprintf("error transforming value: %s", err.Error()), Err: err}
+		}
 	}
-	return s.Send(value)
+
+	return signal.Send(value)
 }
 
 // Register a receiver to a signal.
@@ -96,20 +636,57 @@ func (m *Pool[T]) CreateOrSend(name string, value T) error {
 // This will register a receiver to a signal inside of the pool.
 //
 // If the signal does not exist, it will be created.
+// In strict mode, an error is returned instead.
 //
 // This is a shorthand.
 func (m *Pool[T]) Listen(name string, r func(Signal[T], T) error) (Receiver[T], error) {
-	return m.Get(name).Listen(r)
+	var signal, err = m.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	return signal.Listen(r)
+}
+
+// Listen to multiple signals with the same function.
+//
+// ** Will initialize new signals if they do not exist. **
+//
+// The returned function disconnects the function from all of the signals.
+// If connecting to any of the signals fails, the function is disconnected
+// from the signals it was already connected to.
+func (m *Pool[T]) SubscribeMany(names []string, r func(Signal[T], T) error) (unsubscribe func(), err error) {
+	var receivers = make([]Receiver[T], 0, len(names))
+	unsubscribe = func() {
+		for _, receiver := range receivers {
+			receiver.Disconnect()
+		}
+	}
+
+	for _, name := range names {
+		receiver, err := m.Listen(name, r)
+		if err != nil {
+			unsubscribe()
+			return nil, err
+		}
+		receivers = append(receivers, receiver)
+	}
+
+	return unsubscribe, nil
+}
+
+// Check if the signal with the given name has any receivers.
+//
+// Returns false if the signal does not exist, the signal is not created.
+func (m *Pool[T]) HasReceivers(name string) bool {
+	var signal, ok = m.load(name)
+	return ok && signal.HasReceivers()
 }
 
 // Get a signal by name.
 //
 // ** Will initialize a new signal if none exists. **
+//
+// Signals are created even in strict mode, use Declare to make this explicit.
 func (m *Pool[T]) Get(name string) Signal[T] {
-	if signal, ok := m.load(name); ok {
-		return signal
-	}
-	var s = &signal[T]{name: name, receivers: make([]Receiver[T], 0), mu: &sync.Mutex{}}
-	m.store(name, s)
-	return s
+	return m.loadOrCreate(name)
 }
@@ -0,0 +1,1215 @@
+package signals_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Nigel2392/go-signals"
+)
+
+var poolConstructors = map[string]func() *signals.Pool[string]{
+	"Map":     func() *signals.Pool[string] { return signals.NewPool[string]() },
+	"SyncMap": func() *signals.Pool[string] { return signals.NewSyncPool[string]() },
+}
+
+func TestPoolConcurrentGet(t *testing.T) {
+	for name, newPool := range poolConstructors {
+		t.Run(name, func(t *testing.T) {
+			var p = newPool()
+			var wg sync.WaitGroup
+			var got = make([]signals.Signal[string], 64)
+			for i := range got {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					got[i] = p.Get("concurrent")
+				}(i)
+			}
+			wg.Wait()
+
+			for i, signal := range got {
+				if signal != got[0] {
+					t.Fatalf("Expected all goroutines to receive the same signal, goroutine %d did not", i)
+				}
+			}
+
+			var count int
+			p.Range(func(signal signals.Signal[string]) bool {
+				count++
+				return true
+			})
+			if count != 1 {
+				t.Errorf("Expected 1 signal in the pool, got %d", count)
+			}
+		})
+	}
+}
+
+func BenchmarkPoolGet(b *testing.B) {
+	for name, newPool := range poolConstructors {
+		b.Run(name, func(b *testing.B) {
+			var p = newPool()
+			var names = make([]string, 64)
+			for i := range names {
+				names[i] = strconv.Itoa(i)
+				p.Get(names[i])
+			}
+
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				var i int
+				for pb.Next() {
+					p.Get(names[i%len(names)])
+					i++
+				}
+			})
+		})
+	}
+}
+
+func TestPoolReconfigure(t *testing.T) {
+	var p = signals.NewPool[string]()
+	var old = p.Get("reconfigure")
+
+	var started = make(chan struct{})
+	var release = make(chan struct{})
+	var mu sync.Mutex
+	var received = make([]string, 0)
+
+	old.Listen(func(signal signals.Signal[string], value string) error {
+		if value == "slow" {
+			close(started)
+			<-release
+		}
+		mu.Lock()
+		received = append(received, value)
+		mu.Unlock()
+		return nil
+	})
+
+	var slowErr = make(chan error)
+	go func() {
+		slowErr <- old.Send("slow")
+	}()
+	<-started
+
+	var done = make(chan signals.Signal[string])
+	go func() {
+		done <- p.Reconfigure("reconfigure", signals.WithMaxValueSize[string](8))
+	}()
+
+	close(release)
+	if err := <-slowErr; err != nil {
+		t.Fatalf("Expected the in-flight send to complete, got %s", err.Error())
+	}
+
+	var next = <-done
+	if next == old {
+		t.Fatal("Expected a new signal to be created")
+	}
+	if p.Get("reconfigure") != next {
+		t.Fatal("Expected the pool to return the new signal")
+	}
+
+	if err := p.Send("reconfigure", "fast"); err != nil {
+		t.Errorf("Expected no errors, got %s", err.Error())
+	}
+	if err := p.Send("reconfigure", "way too large"); err == nil {
+		t.Error("Expected the new options to be applied")
+	}
+
+	if _, err := old.Listen(func(signal signals.Signal[string], value string) error { return nil }); !errors.Is(err, signals.ErrSignalDeleted) {
+		t.Errorf("Expected ErrSignalDeleted listening on the replaced signal, got %v", err)
+	}
+	if err := old.Send("stale"); !errors.Is(err, signals.ErrSignalDeleted) {
+		t.Errorf("Expected ErrSignalDeleted sending on the replaced signal, got %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if strings.Join(received, ",") != "slow,fast" {
+		t.Errorf("Expected receivers to keep receiving across the reconfiguration, got %v", received)
+	}
+}
+
+func TestPoolReconfigureStaleDisconnect(t *testing.T) {
+	var hooked = make([]error, 0)
+	var p = signals.NewPool[string](signals.WithErrorHook(func(signal signals.Signal[string], err error) {
+		hooked = append(hooked, err)
+	}))
+	var old = p.Get("stale")
+
+	var calls = make(map[string]int)
+	var newRecv = func(name string) signals.Receiver[string] {
+		return signals.NewRecv(func(signal signals.Signal[string], value string) error {
+			calls[name]++
+			return nil
+		})
+	}
+	var first, second = newRecv("first"), newRecv("second")
+	old.Connect(first, second)
+
+	var next = p.Reconfigure("stale")
+	if old.Count() != 0 {
+		t.Errorf("Expected the replaced signal to be left without receivers, got %d", old.Count())
+	}
+
+	old.Disconnect(first)
+	old.Clear()
+	if len(hooked) != 2 || !errors.Is(hooked[0], signals.ErrSignalDeleted) || !errors.Is(hooked[1], signals.ErrSignalDeleted) {
+		t.Errorf("Expected ErrSignalDeleted to be reported for the disconnect and the clear, got %v", hooked)
+	}
+
+	if err := next.Send("value"); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	if calls["first"] != 1 || calls["second"] != 1 {
+		t.Errorf("Expected the moved receivers to keep receiving, got %v", calls)
+	}
+	if first.Signal() != next || second.Signal() != next {
+		t.Error("Expected the moved receivers to keep the new signal")
+	}
+
+	if err := first.Disconnect(); err != nil {
+		t.Fatalf("Expected the moved receiver to disconnect from the new signal, got %s", err.Error())
+	}
+	if next.Count() != 1 {
+		t.Errorf("Expected 1 receiver after disconnecting, got %d", next.Count())
+	}
+}
+
+func TestPoolReconfigureConcurrent(t *testing.T) {
+	for name, newPool := range poolConstructors {
+		t.Run(name, func(t *testing.T) {
+			var p = newPool()
+			p.Get("reconfigure").SetMeta("team", "core")
+
+			var wg sync.WaitGroup
+			var done = make(chan struct{})
+			var stop = time.Now().Add(500 * time.Millisecond)
+			wg.Add(3)
+			go func() {
+				defer wg.Done()
+				for time.Now().Before(stop) {
+					p.Reconfigure("reconfigure").SetMeta("team", "core")
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for time.Now().Before(stop) {
+					p.FindByMeta("team", "core")
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for time.Now().Before(stop) {
+					p.ExportTopology()
+				}
+			}()
+			go func() {
+				wg.Wait()
+				close(done)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(10 * time.Second):
+				t.Fatal("Expected reconfiguring while ranging over the pool not to deadlock")
+			}
+			if p.Len() != 1 {
+				t.Errorf("Expected a single signal, got %d", p.Len())
+			}
+		})
+	}
+}
+
+func TestPoolDeleteConcurrent(t *testing.T) {
+	for name, newPool := range poolConstructors {
+		t.Run(name, func(t *testing.T) {
+			var p = newPool()
+			for i := 0; i < 1000; i++ {
+				p.Get("delete")
+
+				var reconfigured signals.Signal[string]
+				var wg sync.WaitGroup
+				wg.Add(2)
+				go func() {
+					defer wg.Done()
+					p.Delete("delete")
+				}()
+				go func() {
+					defer wg.Done()
+					reconfigured = p.Reconfigure("delete")
+				}()
+				wg.Wait()
+
+				// Either the reconfigured signal is still in the pool, or it was deleted with it.
+				if p.Len() == 0 && !errors.Is(reconfigured.Send("value"), signals.ErrSignalDeleted) {
+					t.Fatal("Expected a reconfigured signal removed from the pool to be deleted")
+				}
+				p.Delete("delete")
+			}
+		})
+	}
+}
+
+func TestPoolReconfigureState(t *testing.T) {
+	var p = signals.NewPool[string]()
+	var old = p.Get("state")
+	var errFailed = errors.New("failed")
+
+	var intercepted, wrapped int32
+	old.SetMeta("team", "core")
+	old.Use(func(next func(signals.Signal[string], string) error) func(signals.Signal[string], string) error {
+		return func(s signals.Signal[string], value string) error {
+			atomic.AddInt32(&wrapped, 1)
+			return next(s, value)
+		}
+	})
+	old.UsePre(func(name string, value string) (string, bool, error) {
+		atomic.AddInt32(&intercepted, 1)
+		return value, true, nil
+	})
+
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	old.ConnectCtx(ctx, signals.NewRecv(func(signal signals.Signal[string], value string) error {
+		return errFailed
+	}))
+
+	// Reconfiguring concurrently must not lose the receiver.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.Reconfigure("state")
+		}()
+	}
+	wg.Wait()
+
+	var next = p.Get("state")
+	if err := next.Send("value"); !errors.Is(err, errFailed) {
+		t.Errorf("Expected the receiver to be moved to the new signal, got %v", err)
+	}
+	if team, ok := next.Meta("team"); !ok || team != "core" {
+		t.Errorf("Expected the metadata to be kept, got %q", team)
+	}
+	if atomic.LoadInt32(&intercepted) != 1 {
+		t.Errorf("Expected the interceptor to be kept, got %d calls", intercepted)
+	}
+	if atomic.LoadInt32(&wrapped) != 1 {
+		t.Errorf("Expected the middleware to be kept, got %d calls", wrapped)
+	}
+
+	cancel()
+	var deadline = time.Now().Add(time.Second)
+	for next.Send("value") != nil {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the receiver to be disconnected once its context is done")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestPoolReconfigureTargets(t *testing.T) {
+	var p = signals.NewPool[string]()
+	var src = p.Get("src")
+	var forwarded = make(chan string, 1)
+	var mirrored = make(chan string, 1)
+	p.Listen("forwarded", func(signal signals.Signal[string], value string) error {
+		forwarded <- value
+		return nil
+	})
+	p.Listen("mirrored", func(signal signals.Signal[string], value string) error {
+		mirrored <- value
+		return nil
+	})
+
+	if _, err := src.ForwardTo(p.Get("forwarded"), signals.ForwardOptions[string]{}); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	src.Mirror(p.Get("mirrored"))
+	p.Reconfigure("forwarded")
+	p.Reconfigure("mirrored")
+
+	if err := src.Send("value"); err != nil {
+		t.Fatalf("Expected values to be forwarded to the reconfigured signal, got %s", err.Error())
+	}
+	for name, received := range map[string]chan string{"forwarded": forwarded, "mirrored": mirrored} {
+		select {
+		case value := <-received:
+			if value != "value" {
+				t.Errorf("Expected %q to receive the value, got %q", name, value)
+			}
+		case <-time.After(time.Second):
+			t.Errorf("Expected %q to receive the value after it was reconfigured", name)
+		}
+	}
+}
+
+func TestPoolConnectAll(t *testing.T) {
+	var p = signals.NewPool[string](signals.RequireReceivers[string]())
+	p.Get("signal.1")
+	p.Get("signal.2")
+	p.Get("signal.3")
+
+	var received = make(map[string]int)
+	var err = p.ConnectAll(signals.NewRecv(func(signal signals.Signal[string], value string) error {
+		received[signal.Name()]++
+		return nil
+	}))
+	if err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+
+	for _, name := range []string{"signal.1", "signal.2", "signal.3"} {
+		if err := p.Send(name, "This is a signal message!"); err != nil {
+			t.Errorf("Expected no errors sending to %s, got %s", name, err.Error())
+		}
+		if received[name] != 1 {
+			t.Errorf("Expected the receiver to receive from %s once, got %d", name, received[name])
+		}
+	}
+
+	p.Get("signal.4")
+	if err := p.Send("signal.4", "This is a signal message!"); !errors.Is(err, signals.ErrNoReceivers) {
+		t.Errorf("Expected signals created afterwards not to be connected, got %v", err)
+	}
+}
+
+func TestPoolValueTransformer(t *testing.T) {
+	var p = signals.NewPool[any]()
+	var errRejected = errors.New("rejected")
+	var received = make([]any, 0)
+
+	p.Listen("transform", func(signal signals.Signal[any], value any) error {
+		received = append(received, value)
+		return nil
+	})
+
+	p.SetValueTransformer(func(name string, value any) (any, error) {
+		switch v := value.(type) {
+		case string:
+			return strings.ToUpper(v), nil
+		case int:
+			return nil, errRejected
+		}
+		return value, nil
+	})
+
+	if err := p.Send("transform", "hello"); err != nil {
+		t.Errorf("Expected no errors, got %s", err.Error())
+	}
+	if err := p.CreateOrSend("transform", "world"); err != nil {
+		t.Errorf("Expected no errors, got %s", err.Error())
+	}
+	if err := p.Send("transform", 1); !errors.Is(err, errRejected) {
+		t.Errorf("Expected the transformer's error, got %v", err)
+	}
+
+	if len(received) != 2 || received[0] != "HELLO" || received[1] != "WORLD" {
+		t.Errorf("Expected the transformed values, got %v", received)
+	}
+}
+
+// Receiver which holds values until a batch is full, or it is flushed.
+type batchReceiver struct {
+	signals.Receiver[string]
+	size    int
+	buffer  []string
+	flushed []string
+}
+
+func newBatchReceiver(size int) *batchReceiver {
+	var r = &batchReceiver{size: size}
+	r.Receiver = signals.NewRecv(func(signal signals.Signal[string], value string) error {
+		r.buffer = append(r.buffer, value)
+		if len(r.buffer) >= r.size {
+			return r.Flush()
+		}
+		return nil
+	})
+	return r
+}
+
+func (r *batchReceiver) Flush() error {
+	r.flushed = append(r.flushed, r.buffer...)
+	r.buffer = nil
+	return nil
+}
+
+func TestPoolCloseFlushes(t *testing.T) {
+	var p = signals.NewPool[string]()
+	var batch = newBatchReceiver(10)
+	p.Get("batched").Connect(batch)
+
+	p.Send("batched", "first")
+	p.Send("batched", "second")
+	if len(batch.flushed) != 0 {
+		t.Fatalf("Expected the batch to hold on to the values, got %v", batch.flushed)
+	}
+
+	if err := p.Close(); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+
+	if strings.Join(batch.flushed, ",") != "first,second" {
+		t.Errorf("Expected Close to flush the batch, got %v", batch.flushed)
+	}
+	if err := p.Send("batched", "third"); !errors.Is(err, signals.ErrSignalNotFound) {
+		t.Errorf("Expected the signal to be removed from the pool, got %v", err)
+	}
+}
+
+func TestPoolNamespace(t *testing.T) {
+	var p = signals.NewPool[string]()
+	var users = p.Namespace("users")
+	var orders = p.Namespace("orders")
+
+	var received = make(map[string][]string)
+	var record = func(signal signals.Signal[string], value string) error {
+		received[signal.Name()] = append(received[signal.Name()], value)
+		return nil
+	}
+
+	users.Listen("created", record)
+	orders.Listen("created", record)
+
+	if err := users.Send("created", "alice"); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	if err := orders.SendGlobal("order-1"); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+
+	if strings.Join(received["users.created"], ",") != "alice" {
+		t.Errorf("Expected users.created to only receive from its namespace, got %v", received["users.created"])
+	}
+	if strings.Join(received["orders.created"], ",") != "order-1" {
+		t.Errorf("Expected orders.created to only receive from its namespace, got %v", received["orders.created"])
+	}
+
+	var names = make([]string, 0)
+	p.Range(func(signal signals.Signal[string]) bool {
+		names = append(names, signal.Name())
+		return true
+	})
+	sort.Strings(names)
+	if strings.Join(names, ",") != "orders.created,users.created" {
+		t.Errorf("Expected the parent to see the prefixed names, got %v", names)
+	}
+
+	if p.Get("users.created") != users.Get("created") {
+		t.Error("Expected the namespace to share its signals with the parent")
+	}
+
+	if err := users.Close(); err != nil {
+		t.Fatalf("Expected no errors closing the namespace, got %s", err.Error())
+	}
+	if err := orders.Send("created", "order-2"); err != nil {
+		t.Errorf("Expected closing a namespace to leave other namespaces intact, got %v", err)
+	}
+}
+
+func TestPoolSubscribeMany(t *testing.T) {
+	for name, newPool := range poolConstructors {
+		t.Run(name, func(t *testing.T) {
+			var p = newPool()
+			var names = []string{"created", "updated", "deleted"}
+			var received = make(map[string]int)
+			var unsubscribe, err = p.SubscribeMany(names, func(signal signals.Signal[string], value string) error {
+				received[signal.Name()]++
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Expected no errors, got %s", err.Error())
+			}
+
+			for _, name := range names {
+				if err := p.Send(name, "Hello World!"); err != nil {
+					t.Errorf("Expected no errors sending to %q, got %s", name, err.Error())
+				}
+			}
+			for _, name := range names {
+				if received[name] != 1 {
+					t.Errorf("Expected the handler to be called once for %q, got %d", name, received[name])
+				}
+			}
+
+			unsubscribe()
+			for _, name := range names {
+				if err := p.Send(name, "Hello World!"); err != nil {
+					t.Errorf("Expected no errors sending to %q after unsubscribing, got %s", name, err.Error())
+				}
+				if received[name] != 1 {
+					t.Errorf("Expected no further calls for %q, got %d", name, received[name])
+				}
+			}
+		})
+	}
+}
+
+func TestPoolStale(t *testing.T) {
+	var clock = newFakeClock()
+	var p = signals.NewPool[string]()
+	var noop = func(signal signals.Signal[string], value string) error { return nil }
+
+	var active = signals.NewHeartbeatRecv(noop, signals.WithClock(clock))
+	var idle = signals.NewHeartbeatRecv(noop, signals.WithClock(clock))
+	p.Get("active").Connect(active)
+	p.Get("idle").Connect(idle)
+	p.Listen("plain", noop)
+
+	if stale := p.Stale(25 * time.Millisecond); len(stale) != 0 {
+		t.Errorf("Expected no stale receivers right after connecting, got %v", stale)
+	}
+
+	clock.Advance(50 * time.Millisecond)
+	if err := p.Send("active", "ping"); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+
+	var stale = p.Stale(25 * time.Millisecond)
+	if len(stale) != 1 || stale[0] != idle.ID() {
+		t.Errorf("Expected only the idle receiver %d to be stale, got %v", idle.ID(), stale)
+	}
+
+	if last := active.(signals.Heartbeat).LastActive(); !last.Equal(clock.Now()) {
+		t.Errorf("Expected the active receiver to have received at %s, got %s", clock.Now(), last)
+	}
+}
+
+func TestPoolForwardRecv(t *testing.T) {
+	var a = signals.NewPool[string]()
+	var b = signals.NewPool[string]()
+
+	var received []string
+	b.Listen("audit", func(signal signals.Signal[string], value string) error {
+		received = append(received, signal.Name()+":"+value)
+		return nil
+	})
+	a.Get("created").Connect(signals.NewPoolForwardRecv(b, "audit"))
+
+	if err := a.Send("created", "alice"); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	if strings.Join(received, ",") != "audit:alice" {
+		t.Errorf("Expected the value to be forwarded to the other pool, got %v", received)
+	}
+
+	var errFailed = errors.New("audit failed")
+	b.Listen("audit", func(signal signals.Signal[string], value string) error {
+		return errFailed
+	})
+	if err := a.Send("created", "bob"); !errors.Is(err, errFailed) {
+		t.Errorf("Expected the target's error to be returned, got %v", err)
+	}
+}
+
+func TestPoolStrictMode(t *testing.T) {
+	for name, newPool := range poolConstructors {
+		t.Run(name, func(t *testing.T) {
+			var p = newPool()
+			p.SetStrictMode(true)
+
+			if err := p.Send("undeclared", "Hello World!"); !errors.Is(err, signals.ErrSignalNotFound) {
+				t.Errorf("Expected ErrSignalNotFound sending to an undeclared signal, got %v", err)
+			}
+			if err := p.CreateOrSend("undeclared", "Hello World!"); !errors.Is(err, signals.ErrSignalNotFound) {
+				t.Errorf("Expected ErrSignalNotFound creating an undeclared signal, got %v", err)
+			}
+			var noop = func(signal signals.Signal[string], value string) error { return nil }
+			if _, err := p.Listen("undeclared", noop); !errors.Is(err, signals.ErrSignalNotFound) {
+				t.Errorf("Expected ErrSignalNotFound listening to an undeclared signal, got %v", err)
+			}
+
+			p.Declare("declared")
+			if _, err := p.Listen("declared", noop); err != nil {
+				t.Fatalf("Expected no errors listening to a declared signal, got %s", err.Error())
+			}
+			if err := p.Send("declared", "Hello World!"); err != nil {
+				t.Errorf("Expected no errors sending to a declared signal, got %s", err.Error())
+			}
+			if err := p.Namespace("users").CreateOrSend("undeclared", "Hello World!"); !errors.Is(err, signals.ErrSignalNotFound) {
+				t.Errorf("Expected namespaces to share strict mode, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPoolOnReceiverChange(t *testing.T) {
+	var p = signals.NewPool[string]()
+	var events []signals.ReceiverChangeEvent
+	p.OnReceiverChange(func(event signals.ReceiverChangeEvent) {
+		// Inspecting the pool from the hook must not deadlock.
+		p.Get(event.Signal)
+		events = append(events, event)
+	})
+
+	var noop = func(signal signals.Signal[string], value string) error { return nil }
+	var first, _ = p.Listen("created", noop)
+	var second, _ = p.Namespace("users").Listen("deleted", noop)
+	first.Disconnect()
+	p.Get("users.deleted").Clear()
+
+	var expected = []signals.ReceiverChangeEvent{
+		{Signal: "created", ReceiverID: first.ID(), Connected: true},
+		{Signal: "users.deleted", ReceiverID: second.ID(), Connected: true},
+		{Signal: "created", ReceiverID: first.ID(), Connected: false},
+		{Signal: "users.deleted", ReceiverID: second.ID(), Connected: false},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d: %+v", len(expected), len(events), events)
+	}
+	for i, event := range events {
+		if event != expected[i] {
+			t.Errorf("Expected event %d to be %+v, got %+v", i, expected[i], event)
+		}
+	}
+}
+
+func TestPoolTypeLock(t *testing.T) {
+	var p = signals.NewPool[any](signals.WithTypeLock[any]())
+	var received []any
+	p.Listen("user.created", func(signal signals.Signal[any], value any) error {
+		received = append(received, value)
+		return nil
+	})
+
+	if err := p.Send("user.created", "alice"); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	if err := p.Send("user.created", 42); !errors.Is(err, signals.ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch sending an int after a string, got %v", err)
+	}
+	if err := p.Send("user.created", "bob"); err != nil {
+		t.Errorf("Expected values of the locked type to be accepted, got %s", err.Error())
+	}
+	if len(received) != 2 {
+		t.Errorf("Expected 2 values to be received, got %v", received)
+	}
+
+	p.Listen("user.deleted", func(signal signals.Signal[any], value any) error { return nil })
+	if err := p.Send("user.deleted", 42); err != nil {
+		t.Errorf("Expected each signal to lock its own type, got %s", err.Error())
+	}
+}
+
+func TestPoolTypeLockConcurrent(t *testing.T) {
+	var p = signals.NewPool[any](signals.WithTypeLock[any]())
+	var signal = p.Get("user.created")
+	signal.Listen(func(signal signals.Signal[any], value any) error { return nil })
+	signal.Freeze()
+
+	var (
+		wg         sync.WaitGroup
+		mu         sync.Mutex
+		accepted   = make(map[string]int)
+		mismatches int
+	)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var value any = i
+			if i%2 == 0 {
+				value = strconv.Itoa(i)
+			}
+			var err = signal.Send(value)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				accepted[reflect.TypeOf(value).String()]++
+			case errors.Is(err, signals.ErrTypeMismatch):
+				mismatches++
+			default:
+				t.Errorf("Expected nil or ErrTypeMismatch, got %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if len(accepted) != 1 || mismatches != 50 {
+		t.Errorf("Expected a single type to be locked, got %v accepted and %d mismatches", accepted, mismatches)
+	}
+}
+
+func TestPoolHasReceivers(t *testing.T) {
+	for name, newPool := range poolConstructors {
+		t.Run(name, func(t *testing.T) {
+			var p = newPool()
+			if p.HasReceivers("missing") {
+				t.Error("Expected a missing signal to have no receivers")
+			}
+
+			var count int
+			p.Range(func(signal signals.Signal[string]) bool {
+				count++
+				return true
+			})
+			if count != 0 {
+				t.Errorf("Expected HasReceivers not to create a signal, got %d signals", count)
+			}
+
+			p.Get("empty")
+			if p.HasReceivers("empty") {
+				t.Error("Expected a signal without receivers to have no receivers")
+			}
+
+			p.Listen("full", func(signal signals.Signal[string], value string) error { return nil })
+			if !p.HasReceivers("full") || !p.Get("full").HasReceivers() {
+				t.Error("Expected a signal with a receiver to have receivers")
+			}
+		})
+	}
+}
+
+func TestPoolWaitForReceiver(t *testing.T) {
+	var p = signals.NewPool[string]()
+	var done = make(chan error, 1)
+	go func() {
+		done <- p.WaitForReceiver(context.Background(), "ready")
+	}()
+
+	select {
+	case err := <-done:
+		t.Fatalf("Expected WaitForReceiver to block without receivers, got %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	p.Listen("ready", func(signal signals.Signal[string], value string) error { return nil })
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected no errors, got %s", err.Error())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected WaitForReceiver to return once a receiver connected")
+	}
+
+	var ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := p.WaitForReceiver(ctx, "never"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the context error, got %v", err)
+	}
+}
+
+func TestPoolSendGlobalConcurrent(t *testing.T) {
+	for name, newPool := range poolConstructors {
+		t.Run(name, func(t *testing.T) {
+			var p = newPool()
+			var received int32
+			var errFailed = errors.New("receiver failed")
+			for i := 0; i < 16; i++ {
+				var i = i
+				p.Listen(strconv.Itoa(i), func(signal signals.Signal[string], value string) error {
+					atomic.AddInt32(&received, 1)
+					if i%4 == 0 {
+						return errFailed
+					}
+					return nil
+				})
+			}
+
+			var err = p.SendGlobalConcurrent("Hello World!", 4)
+			if atomic.LoadInt32(&received) != 16 {
+				t.Errorf("Expected all 16 signals to be sent to, got %d", received)
+			}
+			var signalErr, ok = signals.SignalError(err)
+			if !ok || signalErr.Len() != 4 || !errors.Is(err, errFailed) {
+				t.Errorf("Expected the errors of 4 signals to be aggregated, got %v", err)
+			}
+		})
+	}
+}
+
+func BenchmarkPoolSendGlobal(b *testing.B) {
+	var p = signals.NewPool[string]()
+	for i := 0; i < 64; i++ {
+		p.Listen(strconv.Itoa(i), func(signal signals.Signal[string], value string) error {
+			// Simulate a receiver waiting on I/O.
+			time.Sleep(100 * time.Microsecond)
+			return nil
+		})
+	}
+
+	b.Run("Sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			p.SendGlobal("Hello World!")
+		}
+	})
+	b.Run("Concurrent", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			p.SendGlobalConcurrent("Hello World!")
+		}
+	})
+}
+
+func TestPoolFindByMeta(t *testing.T) {
+	for name, newPool := range poolConstructors {
+		t.Run(name, func(t *testing.T) {
+			var p = newPool()
+			p.Get("payments").SetMeta("critical", "true")
+			p.Get("orders").SetMeta("critical", "true")
+			p.Get("orders").SetMeta("team", "sales")
+			p.Get("newsletter").SetMeta("critical", "false")
+			p.Get("untagged")
+
+			if value, ok := p.Get("orders").Meta("team"); !ok || value != "sales" {
+				t.Errorf("Expected the team to be sales, got %q", value)
+			}
+			if _, ok := p.Get("untagged").Meta("team"); ok {
+				t.Error("Expected no metadata on an untagged signal")
+			}
+
+			var names = make([]string, 0)
+			for _, signal := range p.FindByMeta("critical", "true") {
+				names = append(names, signal.Name())
+			}
+			sort.Strings(names)
+			if strings.Join(names, ",") != "orders,payments" {
+				t.Errorf("Expected the critical signals to be found, got %v", names)
+			}
+		})
+	}
+}
+
+func TestPoolApply(t *testing.T) {
+	var pool = signals.NewPool[string]()
+	var received = make(map[string][]string)
+	var registry = map[string]func(signals.Signal[string], string) error{
+		"audit": func(s signals.Signal[string], value string) error {
+			received["audit"] = append(received["audit"], s.Name()+":"+value)
+			return nil
+		},
+		"mail": func(s signals.Signal[string], value string) error {
+			received["mail"] = append(received["mail"], s.Name()+":"+value)
+			return nil
+		},
+	}
+
+	var err = pool.Apply(signals.PoolConfig{
+		Signals: []signals.SignalConfig{
+			{Name: "apply-created", Receivers: []string{"audit", "mail"}},
+			{Name: "apply-deleted", Receivers: []string{"audit"}},
+		},
+	}, registry)
+	if err != nil {
+		t.Fatalf("Expected the config to be applied, got %s", err.Error())
+	}
+
+	pool.Send("apply-created", "a")
+	pool.Send("apply-deleted", "b")
+
+	if strings.Join(received["audit"], ",") != "apply-created:a,apply-deleted:b" {
+		t.Errorf("Expected audit to receive both signals, got %v", received["audit"])
+	}
+	if strings.Join(received["mail"], ",") != "apply-created:a" {
+		t.Errorf("Expected mail to receive only the created signal, got %v", received["mail"])
+	}
+
+	err = pool.Apply(signals.PoolConfig{
+		Signals: []signals.SignalConfig{{Name: "apply-unknown", Receivers: []string{"missing"}}},
+	}, registry)
+	if err == nil {
+		t.Error("Expected an error for an unregistered receiver")
+	}
+	if pool.HasReceivers("apply-unknown") {
+		t.Error("Expected no receivers to be connected for an invalid config")
+	}
+}
+
+func TestPoolForwardCycle(t *testing.T) {
+	var p = signals.NewPool[int]()
+	var a, b = p.Get("a"), p.Get("b")
+
+	if _, err := a.ForwardTo(b, signals.ForwardOptions[int]{}); err != nil {
+		t.Fatalf("Expected forwarding from a to b to succeed, got %s", err.Error())
+	}
+
+	// The reconfigured signal keeps forwarding to b, so b must not forward to it.
+	var reconfigured = p.Reconfigure("a")
+	if _, err := b.ForwardTo(reconfigured, signals.ForwardOptions[int]{}); !errors.Is(err, signals.ErrForwardCycle) {
+		t.Fatalf("Expected forwarding from b to the reconfigured a to be rejected with ErrForwardCycle, got %v", err)
+	}
+
+	// A deleted signal no longer forwards anything.
+	p.Delete("a")
+	if _, err := b.ForwardTo(p.Get("a"), signals.ForwardOptions[int]{}); err != nil {
+		t.Fatalf("Expected forwarding from b to a new a to succeed, got %s", err.Error())
+	}
+
+	// Signals with the same names in another pool are unrelated.
+	var other = signals.NewPool[int]()
+	if _, err := other.Get("a").ForwardTo(other.Get("b"), signals.ForwardOptions[int]{}); err != nil {
+		t.Fatalf("Expected forwarding in another pool to succeed, got %s", err.Error())
+	}
+}
+
+func TestPoolDeleteStaleSignal(t *testing.T) {
+	var pool = signals.NewPool[string]()
+	var signal = pool.Get("stale")
+	signal.Listen(func(s signals.Signal[string], value string) error {
+		return nil
+	})
+
+	pool.Delete("stale")
+
+	if _, err := signal.Listen(func(s signals.Signal[string], value string) error {
+		return nil
+	}); !errors.Is(err, signals.ErrSignalDeleted) {
+		t.Errorf("Expected connecting to a deleted signal to return ErrSignalDeleted, got %v", err)
+	}
+	if err := signal.Send("value"); !errors.Is(err, signals.ErrSignalDeleted) {
+		t.Errorf("Expected sending on a deleted signal to return ErrSignalDeleted, got %v", err)
+	}
+	if err := signals.Wait(signal.SendAsync("value")); !errors.Is(err, signals.ErrSignalDeleted) {
+		t.Errorf("Expected sending asynchronously on a deleted signal to return ErrSignalDeleted, got %v", err)
+	}
+
+	if err := pool.Get("stale").Send("value"); errors.Is(err, signals.ErrSignalDeleted) {
+		t.Error("Expected a new signal with the same name to be usable")
+	}
+}
+
+func TestPoolLenNames(t *testing.T) {
+	var pool = signals.NewPool[string]()
+	if pool.Len() != 0 || len(pool.Names()) != 0 {
+		t.Fatalf("Expected an empty pool, got %d signals", pool.Len())
+	}
+
+	pool.Get("first")
+	pool.Get("second")
+	pool.Namespace("third").Get("signal")
+
+	if pool.Len() != 3 {
+		t.Errorf("Expected 3 signals, got %d", pool.Len())
+	}
+
+	var names = pool.Names()
+	sort.Strings(names)
+	if strings.Join(names, ",") != "first,second,third.signal" {
+		t.Errorf("Expected the names of all 3 signals, got %v", names)
+	}
+
+	var namespaced = pool.Namespace("third").Names()
+	if len(namespaced) != 1 || namespaced[0] != "signal" {
+		t.Errorf("Expected the namespace to return its names without the prefix, got %v", namespaced)
+	}
+
+	pool.Delete("first")
+	if pool.Len() != 2 {
+		t.Errorf("Expected 2 signals after deleting one, got %d", pool.Len())
+	}
+}
+
+func TestPoolSendGlobalErrors(t *testing.T) {
+	var pool = signals.NewPool[string]()
+	var errFailed = errors.New("failed")
+	var received = make(map[string]int)
+	for _, name := range []string{"first", "middle", "last"} {
+		pool.Listen(name, func(s signals.Signal[string], value string) error {
+			received[s.Name()]++
+			if s.Name() == "middle" {
+				return errFailed
+			}
+			return nil
+		})
+	}
+
+	var err = pool.SendGlobal("value")
+	var signalErr, ok = signals.SignalError(err)
+	if !ok || signalErr.Len() != 1 || !errors.Is(err, errFailed) {
+		t.Fatalf("Expected an Error containing the middle signal's error, got %v", err)
+	}
+
+	for _, name := range []string{"first", "middle", "last"} {
+		if received[name] != 1 {
+			t.Errorf("Expected %q to receive the value once, got %d", name, received[name])
+		}
+	}
+}
+
+func TestPoolSendGlobalCreate(t *testing.T) {
+	for name, newPool := range poolConstructors {
+		t.Run(name, func(t *testing.T) {
+			var p = newPool()
+			p.Listen("created", func(s signals.Signal[string], value string) error {
+				p.Get("created." + value)
+				return nil
+			})
+
+			var done = make(chan error, 1)
+			go func() {
+				done <- p.SendGlobal("value")
+			}()
+
+			select {
+			case err := <-done:
+				if err != nil {
+					t.Fatalf("Expected no errors, got %s", err.Error())
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("Expected a receiver to be able to create signals while sending globally")
+			}
+			if p.Len() != 2 {
+				t.Errorf("Expected the receiver to create a signal, got %v", p.Names())
+			}
+		})
+	}
+}
+
+func TestPoolSendGlobalNoReceivers(t *testing.T) {
+	var pool = signals.NewPool[string](signals.RequireReceivers[string]())
+	var received int
+	pool.Get("empty")
+	pool.Listen("populated", func(s signals.Signal[string], value string) error {
+		received++
+		return nil
+	})
+
+	if err := pool.SendGlobal("value"); err != nil {
+		t.Errorf("Expected signals without receivers to be skipped, got %s", err.Error())
+	}
+	if err := pool.SendGlobalConcurrent("value"); err != nil {
+		t.Errorf("Expected signals without receivers to be skipped concurrently, got %s", err.Error())
+	}
+	if received != 2 {
+		t.Errorf("Expected the populated signal to receive 2 values, got %d", received)
+	}
+
+	if err := pool.Send("empty", "value"); !errors.Is(err, signals.ErrNoReceivers) {
+		t.Errorf("Expected sending to the empty signal directly to return ErrNoReceivers, got %v", err)
+	}
+
+	// A receiver failing while forwarding to the empty signal is not skipped.
+	var errFailed = errors.New("failed")
+	var failing = pool.Get("failing")
+	failing.Connect(signals.NewPoolForwardRecv(pool, "empty"))
+	failing.Listen(func(s signals.Signal[string], value string) error {
+		return errFailed
+	})
+	for method, sendGlobal := range map[string]func(string) error{
+		"SendGlobal":           pool.SendGlobal,
+		"SendGlobalConcurrent": func(value string) error { return pool.SendGlobalConcurrent(value) },
+	} {
+		if err := sendGlobal("value"); !errors.Is(err, errFailed) {
+			t.Errorf("Expected %s to return the receiver's error, got %v", method, err)
+		}
+	}
+}
+
+func TestPoolTopology(t *testing.T) {
+	var staging = signals.NewPool[string]()
+	staging.Declare("orders", signals.Sticky[string](), signals.WithDefaultTimeout[string](time.Second)).SetMeta("team", "sales")
+	staging.Declare("payments", signals.WithRecover[string](), signals.StopOnError[string](), signals.WithMaxValueSize[string](64))
+	staging.Listen("orders", func(s signals.Signal[string], value string) error {
+		return nil
+	})
+
+	var exported = staging.ExportTopology()
+	data, err := json.Marshal(exported)
+	if err != nil {
+		t.Fatalf("Expected the topology to be serializable, got %s", err.Error())
+	}
+
+	var decoded signals.Topology
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Expected the topology to be deserializable, got %s", err.Error())
+	}
+
+	var production = signals.NewPool[string]()
+	production.ImportTopology(decoded)
+
+	var imported = production.ExportTopology()
+	var byName = func(topology signals.Topology) {
+		sort.Slice(topology.Signals, func(i, j int) bool {
+			return topology.Signals[i].Name < topology.Signals[j].Name
+		})
+	}
+	byName(exported)
+	byName(imported)
+	if !reflect.DeepEqual(exported, imported) {
+		t.Errorf("Expected the imported topology to match the exported topology, got %+v and %+v", exported, imported)
+	}
+
+	if production.HasReceivers("orders") {
+		t.Error("Expected receivers not to be imported")
+	}
+	if team, _ := production.Get("orders").Meta("team"); team != "sales" {
+		t.Errorf("Expected the metadata to be imported, got %q", team)
+	}
+	if exported.Signals[0].Options.DefaultTimeout != time.Second || !exported.Signals[1].Options.Recover {
+		t.Errorf("Expected the options to be exported, got %+v", exported)
+	}
+
+	// Importing into existing signals keeps the signals, their receivers and state.
+	var fellBack bool
+	var existing = signals.NewPool[string]()
+	var held = existing.Get("payments")
+	held.SetFallback(signals.NewRecv(func(s signals.Signal[string], value string) error {
+		fellBack = true
+		return nil
+	}))
+	existing.Listen("payments", func(s signals.Signal[string], value string) error {
+		return errors.New("declined")
+	})
+	existing.ImportTopology(decoded)
+	if existing.Get("payments") != held {
+		t.Fatal("Expected the existing signal to be kept")
+	}
+	if held.Count() != 1 {
+		t.Fatalf("Expected the receivers to be kept, got %d", held.Count())
+	}
+	if err := held.Send("payment"); errors.Is(err, signals.ErrSignalDeleted) {
+		t.Fatalf("Expected the held signal to keep working, got %s", err.Error())
+	}
+	if !fellBack {
+		t.Error("Expected the fallback to be kept")
+	}
+}
+
+func TestPoolSetDefaultReceiver(t *testing.T) {
+	var pool = signals.NewPool[string]()
+	pool.Get("existing")
+
+	var received = make(map[string][]string)
+	pool.SetDefaultReceiver(func(name string) signals.Receiver[string] {
+		return signals.NewRecv(func(s signals.Signal[string], value string) error {
+			received[name] = append(received[name], value)
+			return nil
+		})
+	})
+
+	pool.Get("first")
+	pool.Listen("second", func(s signals.Signal[string], value string) error {
+		return nil
+	})
+
+	for _, name := range []string{"first", "second"} {
+		if pool.Get(name).Count() != map[string]int{"first": 1, "second": 2}[name] {
+			t.Errorf("Expected the default receiver to be attached to %q, got %d receivers", name, pool.Get(name).Count())
+		}
+		if err := pool.Send(name, "value-"+name); err != nil {
+			t.Fatalf("Expected no error sending to %q, got %s", name, err.Error())
+		}
+		if len(received[name]) != 1 || received[name][0] != "value-"+name {
+			t.Errorf("Expected the default receiver of %q to fire once, got %v", name, received[name])
+		}
+	}
+
+	if pool.HasReceivers("existing") {
+		t.Error("Expected signals created before setting the default receiver not to be affected")
+	}
+}
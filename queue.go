@@ -0,0 +1,22 @@
+package signals
+
+import (
+	"context"
+	"time"
+)
+
+// Send a signal to all receivers, within a time budget.
+//
+// Receivers are called synchronously until the budget has been exceeded,
+// the remaining receivers are then called in the background.
+//
+// A receiver which was started within the budget will always complete before this returns.
+//
+// Returns an error, if any of the synchronously called receivers return an error.
+//
+// Errors from receivers called in the background are passed to the error hook.
+// The fallback receiver is only called if no receivers were called in the background.
+func (s *signal[T]) SendOrQueue(budget time.Duration, value T) error {
+	var hooks = sendHooks[T]{deadline: time.Now().Add(budget)}
+	return s.sendContext(context.Background(), value, hooks).Err()
+}
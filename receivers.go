@@ -1,10 +1,16 @@
 package signals
 
 import (
+	"context"
+	"math/rand"
 	"sync"
-	"unsafe"
+	"sync/atomic"
+	"time"
 )
 
+// Counter used to assign a unique ID to every receiver.
+var receiverIDs atomic.Uint64
+
 // Receiver interface
 // This will be registered to any signals that it wants to receive.
 // The receiver will be called when the signal is sent.
@@ -22,46 +28,353 @@ type Receiver[T any] interface {
 	ID() uint64
 }
 
+// Receivers which receive the context of the send.
+//
+// When a value is sent with SendContext, ReceiveContext is called
+// instead of Receive, so that the receiver can stop once the context is done.
+// Other sends call Receive.
+type ContextReceiver[T any] interface {
+	Receiver[T]
+
+	// Receives the context, signal and value from the signal.
+	ReceiveContext(context.Context, Signal[T], T) error
+}
+
+// Flusher receivers hold on to values, and must be flushed before shutting down.
+//
+// This is the case for receivers which batch or queue values.
+type Flusher interface {
+	// Flush any values held by the receiver.
+	Flush() error
+}
+
+// Receivers which keep track of when they were connected to a signal.
+type ConnectionTimer interface {
+	// Return the time the receiver was connected to its signal,
+	// or the zero time if it is not connected.
+	ConnectedAt() time.Time
+}
+
+// Receivers which keep track of when they last received a value.
+type Heartbeat interface {
+	// Return the time the receiver last received a value,
+	// or the zero time if it has not received any values.
+	LastActive() time.Time
+}
+
+// Receivers with a priority, receivers with a higher priority are called first.
+type Prioritized interface {
+	// Return the priority of the receiver.
+	Priority() int
+}
+
+// Receivers with a weight, used by SendWeighted to select a receiver.
+type Weighted interface {
+	// Return the weight of the receiver.
+	Weight() int
+}
+
+// Keyed receivers can be referenced by other receivers by their key.
+type Keyed interface {
+	// Return the key of the receiver.
+	Key() string
+}
+
+// Dependent receivers declare which receivers must be called before them.
+type Dependent interface {
+	// Return the keys of the receivers which must be called before this receiver.
+	DependsOn() []string
+}
+
+// Options for receivers created with NewRecv.
+type receiverOptions struct {
+	key       string
+	dependsOn []string
+	priority  int
+	clock     Clock
+	rng       *rand.Rand
+	interval  time.Duration
+
+	// Only call the callback once, and disconnect afterwards.
+	once bool
+}
+
+// Options of receivers created without any options.
+//
+// Receivers share these, so that connecting many receivers stays cheap.
+var defaultReceiverOptions = receiverOptions{clock: RealClock}
+
+// Option for configuring a receiver created with NewRecv.
+type RecvOption func(*receiverOptions)
+
+// Set the key of the receiver.
+//
+// Other receivers can use this key to declare a dependency on this receiver.
+func WithKey(key string) RecvOption {
+	return func(o *receiverOptions) {
+		o.key = key
+	}
+}
+
+// Declare the keys of receivers which must be called before this receiver.
+//
+// Dependencies on keys which are not connected to the signal are ignored.
+func WithDependsOn(keys ...string) RecvOption {
+	return func(o *receiverOptions) {
+		o.dependsOn = append(o.dependsOn, keys...)
+	}
+}
+
+// Set the priority of the receiver, receivers with a higher priority are called first.
+//
+// Receivers with the same priority are called in the order they were connected in.
+// The default priority is 0.
+func WithPriority(priority int) RecvOption {
+	return func(o *receiverOptions) {
+		o.priority = priority
+	}
+}
+
+// Set the clock used by the receiver to tell the time.
+//
+// This is used to record when the receiver was connected, and by the time-based
+// receivers: NewHeartbeatRecv, NewDebounceRecv and NewWindowStatsRecv.
+// Ticker uses it to wait for the next tick.
+// The default is the real clock.
+func WithClock(clock Clock) RecvOption {
+	return func(o *receiverOptions) {
+		if clock == nil {
+			clock = RealClock
+		}
+		o.clock = clock
+	}
+}
+
+// Set the random number generator used by receivers created with NewSampledRecv.
+//
+// This allows for a generator with a fixed seed to be used in tests.
+// The default is a generator seeded with the current time.
+func WithRand(rng *rand.Rand) RecvOption {
+	return func(o *receiverOptions) {
+		o.rng = rng
+	}
+}
+
+// Set how often receivers created with NewWindowStatsRecv emit statistics.
+//
+// An interval shorter than the window lets the windows of consecutive ticks overlap.
+// The default is the duration of the window.
+func WithEmitInterval(interval time.Duration) RecvOption {
+	return func(o *receiverOptions) {
+		o.interval = interval
+	}
+}
+
+// Only call the callback of the receiver for the first value, used by ListenOnce.
+func listenOnce(o *receiverOptions) {
+	o.once = true
+}
+
 // Underlying receiver struct
 type receiver[T any] struct {
-	signal Signal[T]
-	cb     func(Signal[T], T) error
-	mu     sync.Mutex
+	id          uint64
+	signal      Signal[T]
+	cb          func(Signal[T], T) error
+	mu          sync.Mutex
+	opts        *receiverOptions
+	connectedAt int64 // Unix time in nanoseconds, only set while the receiver is connected.
+	priority    atomic.Int64
+
+	// Set once a receiver created with ListenOnce has been called.
+	fired atomic.Bool
 }
 
 // Initialize a new receiver
-func NewRecv[T any](cb func(Signal[T], T) error) *receiver[T] {
-	return &receiver[T]{cb: cb}
+//
+// Options can be provided to configure the receiver.
+func NewRecv[T any](cb func(Signal[T], T) error, opts ...RecvOption) *receiver[T] {
+	var r = &receiver[T]{id: receiverIDs.Add(1), cb: cb, opts: &defaultReceiverOptions}
+	if len(opts) > 0 {
+		var o = defaultReceiverOptions
+		for _, opt := range opts {
+			opt(&o)
+		}
+		r.opts = &o
+	}
+	r.priority.Store(int64(r.opts.priority))
+	return r
+}
+
+// Return the key of the receiver.
+func (r *receiver[T]) Key() string {
+	return r.opts.key
+}
+
+// Return the keys of the receivers which must be called before this receiver.
+func (r *receiver[T]) DependsOn() []string {
+	return r.opts.dependsOn
+}
+
+// Return the priority of the receiver.
+func (r *receiver[T]) Priority() int {
+	return int(r.priority.Load())
+}
+
+// Set the priority of the receiver, receivers with a higher priority are called first.
+//
+// If the receiver is connected, its signal uses the new priority from the next send onwards.
+// The order of a frozen signal does not change.
+func (r *receiver[T]) SetPriority(priority int) {
+	r.priority.Store(int64(priority))
+	if s, ok := r.Signal().(interface{ reprioritize() }); ok {
+		s.reprioritize()
+	}
 }
 
 // Receives the signal and value from the signal.
+//
+// A receiver created with ListenOnce only calls its callback for the first value,
+// and disconnects itself once the callback returns.
 func (r *receiver[T]) Receive(s Signal[T], value T) error {
+	if !r.opts.once {
+		return r.cb(s, value)
+	}
+
+	if !r.fired.CompareAndSwap(false, true) {
+		return nil
+	}
+	defer r.Disconnect()
 	return r.cb(s, value)
 }
 
 // Disconnects the receiver from the signal.
+//
+// Returns an error wrapping ErrFrozen if the signal is frozen,
+// the receiver stays connected in that case.
 func (r *receiver[T]) Disconnect() error {
-	if r.signal == nil {
-		return e("receiver is not connected to a signal")
+	r.mu.Lock()
+	var signal = r.signal
+	r.mu.Unlock()
+
+	if signal == nil {
+		return wrap(ErrNotConnected)
+	}
+	if s, ok := signal.(interface{ disconnectReceivers([]Receiver[T]) error }); ok {
+		if err := s.disconnectReceivers([]Receiver[T]{r}); err != nil {
+			return err
+		}
+	} else {
+		signal.Disconnect(r)
 	}
-	r.signal.Disconnect(r)
-	r.signal = nil
+	r.Signal(nil)
 	return nil
 }
 
 // Sets the signal on the receiver instance for later use.
 // Returns the signal if there is one.
 // If the signal is already set, overwrite and return new value.
+//
+// Setting the signal records the time the receiver was connected.
 func (r *receiver[T]) Signal(signal ...Signal[T]) Signal[T] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	if len(signal) > 0 {
 		r.signal = signal[0]
+		if r.signal != nil {
+			r.connectedAt = r.opts.clock.Now().UnixNano()
+		}
 	}
 	return r.signal
 }
 
+// Return the clock used by the receiver to tell the time.
+func (r *receiver[T]) clock() Clock {
+	return r.opts.clock
+}
+
+// Return the time the receiver was connected to its signal,
+// or the zero time if it is not connected.
+func (r *receiver[T]) ConnectedAt() time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.signal == nil {
+		return time.Time{}
+	}
+	return time.Unix(0, r.connectedAt)
+}
+
 // Return the unique ID of the receiver.
-// This will be the memory address of the receiver.
+//
+// IDs are assigned in the order receivers are created, starting at 1.
 func (r *receiver[T]) ID() uint64 {
-	var addr = uintptr(unsafe.Pointer(r))
-	return uint64(addr)
+	return r.id
+}
+
+// Receivers which wrap another receiver.
+//
+// Wrappers share the ID of the receiver they wrap, except for
+// receivers connected with ConnectLazy, which construct the receiver they wrap.
+type unwrapper[T any] interface {
+	// Return the wrapped receiver.
+	unwrap() Receiver[T]
+}
+
+// Receivers which wrap another receiver, and can be copied to wrap a different one.
+type wrapper[T any] interface {
+	unwrapper[T]
+	// Return a copy of the wrapper, wrapping the given receiver instead.
+	rewrap(Receiver[T]) Receiver[T]
+}
+
+// Return the receiver as I, or the first receiver it wraps which implements I.
+//
+// Wrappers do not implement optional interfaces such as Flusher or io.Closer
+// themselves, these are implemented by the receiver which is wrapped.
+func unwrapAs[I any, T any](receiver Receiver[T]) (I, bool) {
+	for {
+		if i, ok := receiver.(I); ok {
+			return i, true
+		}
+		var w, ok = receiver.(unwrapper[T])
+		if !ok {
+			var zero I
+			return zero, false
+		}
+		receiver = w.unwrap()
+	}
+}
+
+// Pass the context to the receiver if it implements ContextReceiver,
+// otherwise the receiver is called without the context.
+func receiveContext[T any](ctx context.Context, receiver Receiver[T], s Signal[T], value T) error {
+	if r, ok := receiver.(ContextReceiver[T]); ok {
+		return r.ReceiveContext(ctx, s, value)
+	}
+	return receiver.Receive(s, value)
+}
+
+// Receiver with a priority, wrapping a receiver which does not have one.
+type priorityReceiver[T any] struct {
+	Receiver[T]
+	priority int
+}
+
+// Receives the context, signal and value from the signal.
+//
+// The context is passed on if the wrapped receiver implements ContextReceiver.
+func (r *priorityReceiver[T]) ReceiveContext(ctx context.Context, s Signal[T], value T) error {
+	return receiveContext(ctx, r.Receiver, s, value)
+}
+
+func (r *priorityReceiver[T]) unwrap() Receiver[T] {
+	return r.Receiver
+}
+
+func (r *priorityReceiver[T]) rewrap(inner Receiver[T]) Receiver[T] {
+	return &priorityReceiver[T]{Receiver: inner, priority: r.priority}
+}
+
+// Return the priority of the receiver.
+func (r *priorityReceiver[T]) Priority() int {
+	return r.priority
 }
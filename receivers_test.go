@@ -0,0 +1,561 @@
+package signals_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Nigel2392/go-signals"
+)
+
+// Fake clock which only moves forward when advanced.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(0, 0)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var ch = make(chan time.Time, 1)
+	c.waiters = append(c.waiters, fakeWaiter{at: c.now.Add(d), ch: ch})
+	return ch
+}
+
+// Move the clock forward, firing any timers which have expired.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	var pending = c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(c.now) {
+			pending = append(pending, w)
+			continue
+		}
+		w.ch <- c.now
+	}
+	c.waiters = pending
+}
+
+func TestDebounceRecv(t *testing.T) {
+	var clock = newFakeClock()
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var received = make(chan string, 10)
+
+	signal.Connect(signals.NewDebounceRecv(100*time.Millisecond, func(signal signals.Signal[string], value string) error {
+		received <- value
+		return nil
+	}, signals.WithClock(clock)))
+
+	signal.Send("first")
+	clock.Advance(50 * time.Millisecond)
+	signal.Send("second")
+	clock.Advance(50 * time.Millisecond)
+	signal.Send("third")
+
+	select {
+	case value := <-received:
+		t.Fatalf("Expected no values before the clock advanced, got %q", value)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	clock.Advance(100 * time.Millisecond)
+
+	select {
+	case value := <-received:
+		if value != "third" {
+			t.Errorf("Expected \"third\", got %q", value)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the debounced value to be received")
+	}
+
+	select {
+	case value := <-received:
+		t.Errorf("Expected a single debounced value, also got %q", value)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	var errDebounce = errors.New("debounce failed")
+	var hooked = make(chan error, 1)
+	var failing = signals.New[string](
+		strconv.Itoa(int(time.Now().UnixNano())),
+		signals.WithErrorHook(func(signal signals.Signal[string], err error) { hooked <- err }),
+	)
+	failing.Connect(signals.NewDebounceRecv(100*time.Millisecond, func(signal signals.Signal[string], value string) error {
+		return errDebounce
+	}, signals.WithClock(clock)))
+	failing.Send("value")
+	clock.Advance(100 * time.Millisecond)
+
+	select {
+	case err := <-hooked:
+		if !errors.Is(err, errDebounce) {
+			t.Errorf("Expected the error of the callback to be passed to the error hook, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the error of the callback to be passed to the error hook")
+	}
+}
+
+type domainError struct {
+	cause error
+}
+
+func (e domainError) Error() string {
+	return "domain: " + e.cause.Error()
+}
+
+func TestErrMapRecv(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var errThirdParty = errors.New("third party failure")
+
+	var inner = signals.NewRecv(func(signal signals.Signal[string], value string) error {
+		if value == "fail" {
+			return errThirdParty
+		}
+		if value == "ignore" {
+			return errors.New("ignored")
+		}
+		return nil
+	})
+
+	var receiver = signals.NewErrMapRecv[string](func(err error) error {
+		if err.Error() == "ignored" {
+			return nil
+		}
+		return domainError{cause: err}
+	}, inner)
+	signal.Connect(receiver)
+
+	var err = signal.Send("fail")
+	var target domainError
+	if !errors.As(err, &target) || target.cause != errThirdParty {
+		t.Errorf("Expected the error to be transformed into a domain error, got %v", err)
+	}
+
+	if err = signal.Send("ignore"); err != nil {
+		t.Errorf("Expected the error to be swallowed, got %s", err.Error())
+	}
+
+	if receiver.ID() != inner.ID() {
+		t.Errorf("Expected the receiver to share its ID with the inner receiver")
+	}
+}
+
+func TestErrMapRecvInterfaces(t *testing.T) {
+	type requestIDKey struct{}
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var mapErr = func(err error) error { return domainError{cause: err} }
+
+	signal.Connect(signals.NewErrMapRecv[string](mapErr, signals.NewContextRecv(func(ctx context.Context, signal signals.Signal[string], value string) error {
+		var id, _ = ctx.Value(requestIDKey{}).(string)
+		return errors.New(id)
+	})))
+	var err = signal.SendContext(context.WithValue(context.Background(), requestIDKey{}, "request-1"), "value")
+	var target domainError
+	if !errors.As(err, &target) || target.cause.Error() != "request-1" {
+		t.Errorf("Expected the error of the context receiver to be transformed, got %v", err)
+	}
+
+	var log []string
+	var errPrepare = errors.New("prepare failed")
+	var tx = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	tx.Connect(signals.NewErrMapRecv[string](mapErr, &txReceiver{
+		Receiver:   signals.NewRecv(func(signal signals.Signal[string], value string) error { return nil }),
+		name:       "tx",
+		prepareErr: errPrepare,
+		log:        &log,
+	}))
+	err = tx.SendTx("value")
+	if !errors.As(err, &target) || target.cause != errPrepare {
+		t.Errorf("Expected the error of the transactional receiver to be transformed, got %v", err)
+	}
+	if strings.Join(log, ",") != "prepare:tx" {
+		t.Errorf("Expected the wrapped receiver to be prepared, got %v", log)
+	}
+}
+
+func TestErrMapRecvOrder(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var order = make([]string, 0)
+	var record = func(name string) func(signals.Signal[string], string) error {
+		return func(signal signals.Signal[string], value string) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+	var keep = func(err error) error { return err }
+
+	signal.Connect(
+		signals.NewErrMapRecv[string](keep, signals.NewRecv(record("b"), signals.WithKey("b"), signals.WithDependsOn("a"))),
+		signals.NewErrMapRecv[string](keep, signals.NewRecv(record("a"), signals.WithKey("a"))),
+	)
+
+	if err := signal.Send("This is a signal message!"); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	if strings.Join(order, ",") != "a,b" {
+		t.Errorf("Expected mapped receivers to keep their key and dependencies, got %v", order)
+	}
+}
+
+func TestFailFastRecv(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())), signals.RequireReceivers[string]())
+	var errFailed = errors.New("failed")
+	var calls int
+
+	signal.Connect(signals.NewFailFastRecv(func(signal signals.Signal[string], value string) error {
+		calls++
+		if calls == 2 {
+			return errFailed
+		}
+		return nil
+	}))
+
+	if err := signal.Send("first"); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	if err := signal.Send("second"); !errors.Is(err, errFailed) {
+		t.Fatalf("Expected the receiver's error, got %v", err)
+	}
+	if err := signal.Send("third"); !errors.Is(err, signals.ErrNoReceivers) {
+		t.Errorf("Expected the receiver to have disconnected itself, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected the receiver to be called 2 times, got %d", calls)
+	}
+}
+
+func TestSampledRecv(t *testing.T) {
+	const sends = 10000
+
+	var tests = []struct {
+		rate     float64
+		min, max int
+	}{
+		{rate: 0.0, min: 0, max: 0},
+		{rate: 1.0, min: sends, max: sends},
+		{rate: 0.5, min: sends * 45 / 100, max: sends * 55 / 100},
+	}
+
+	for _, test := range tests {
+		var signal = signals.New[int](strconv.Itoa(int(time.Now().UnixNano())))
+		var calls int
+		signal.Connect(signals.NewSampledRecv(test.rate, func(signal signals.Signal[int], value int) error {
+			calls++
+			return nil
+		}, signals.WithRand(rand.New(rand.NewSource(42)))))
+
+		for i := 0; i < sends; i++ {
+			signal.Send(i)
+		}
+
+		if calls < test.min || calls > test.max {
+			t.Errorf("Expected between %d and %d calls for rate %.1f, got %d", test.min, test.max, test.rate, calls)
+		}
+	}
+}
+
+func TestReceiverConnectedAt(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var receiver = signals.NewRecv(func(signal signals.Signal[string], value string) error {
+		return nil
+	})
+
+	if !receiver.ConnectedAt().IsZero() {
+		t.Fatalf("Expected a zero time before connecting, got %s", receiver.ConnectedAt())
+	}
+
+	var before = time.Now()
+	signal.Connect(receiver)
+	time.Sleep(20 * time.Millisecond)
+
+	var connectedAt = receiver.ConnectedAt()
+	if connectedAt.Before(before) {
+		t.Errorf("Expected the connection time to be after %s, got %s", before, connectedAt)
+	}
+	if since := time.Since(connectedAt); since < 20*time.Millisecond {
+		t.Errorf("Expected the receiver to be connected for at least 20ms, got %s", since)
+	}
+
+	var timer signals.ConnectionTimer = receiver
+	signal.Disconnect(receiver)
+	if !timer.ConnectedAt().IsZero() {
+		t.Errorf("Expected a zero time after disconnecting, got %s", timer.ConnectedAt())
+	}
+}
+
+func TestCollect(t *testing.T) {
+	var signal = signals.New[int](strconv.Itoa(int(time.Now().UnixNano())))
+	var plain int
+
+	for i := 1; i <= 3; i++ {
+		var factor = i
+		signal.Connect(signals.NewFuncRecv(func(signal signals.Signal[int], value int) (int, error) {
+			return value * factor, nil
+		}))
+	}
+	signal.Listen(func(signal signals.Signal[int], value int) error {
+		plain++
+		return nil
+	})
+
+	var results, err = signals.Collect[int, int](signal, 10)
+	if err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+
+	if len(results) != 3 || results[0] != 10 || results[1] != 20 || results[2] != 30 {
+		t.Errorf("Expected results [10 20 30], got %v", results)
+	}
+	if plain != 1 {
+		t.Errorf("Expected other receivers to still receive the value, got %d calls", plain)
+	}
+
+	// Results of wrapped receivers are gathered, the wrappers still apply.
+	var wrapped = signals.New[int](strconv.Itoa(int(time.Now().UnixNano())))
+	wrapped.ConnectFilter(func(value int) bool {
+		return value > 0
+	}, signals.NewFuncRecv(func(signal signals.Signal[int], value int) (int, error) {
+		return value * 2, nil
+	}))
+	wrapped.ConnectPriority(1, signals.NewFuncRecv(func(signal signals.Signal[int], value int) (int, error) {
+		return value, nil
+	}))
+
+	if results, _ = signals.Collect[int, int](wrapped, 10); len(results) != 2 || results[0] != 10 || results[1] != 20 {
+		t.Errorf("Expected results [10 20] from the wrapped receivers, got %v", results)
+	}
+	if results, _ = signals.Collect[int, int](wrapped, -10); len(results) != 1 || results[0] != -10 {
+		t.Errorf("Expected the filtered receiver to be skipped, got %v", results)
+	}
+}
+
+func TestWindowStatsRecv(t *testing.T) {
+	var clock = newFakeClock()
+	var signal = signals.New[int](strconv.Itoa(int(time.Now().UnixNano())))
+	var emitted = make(chan signals.WindowStats, 4)
+
+	signal.Connect(signals.NewWindowStatsRecv[int](time.Minute, func(stats signals.WindowStats) error {
+		emitted <- stats
+		return nil
+	}, signals.WithClock(clock)))
+
+	var start = clock.Now()
+	for _, value := range []int{4, 1, 7} {
+		if err := signal.Send(value); err != nil {
+			t.Fatal(err)
+		}
+		clock.Advance(10 * time.Second)
+	}
+	select {
+	case stats := <-emitted:
+		t.Fatalf("Expected nothing to be emitted before the window ends, got %+v", stats)
+	default:
+	}
+
+	clock.Advance(30 * time.Second)
+	var stats = <-emitted
+	if stats.Count != 3 || stats.Min != 1 || stats.Max != 7 || stats.Avg != 4 {
+		t.Errorf("Expected count 3, min 1, max 7, avg 4, got %+v", stats)
+	}
+	if !stats.Start.Equal(start) || stats.End.Sub(stats.Start) != time.Minute {
+		t.Errorf("Expected a window of one minute from %s, got %s to %s", start, stats.Start, stats.End)
+	}
+
+	// Statistics are emitted for windows without values.
+	clock.Advance(time.Minute)
+	stats = <-emitted
+	if stats.Count != 0 || stats.Min != 0 || stats.Max != 0 || stats.Avg != 0 {
+		t.Errorf("Expected an empty window, got %+v", stats)
+	}
+	if !stats.Start.Equal(start.Add(time.Minute)) {
+		t.Errorf("Expected the window to start at %s, got %s", start.Add(time.Minute), stats.Start)
+	}
+
+	signal.Send(5)
+	clock.Advance(time.Minute)
+	stats = <-emitted
+	if stats.Count != 1 || stats.Min != 5 || stats.Max != 5 || stats.Avg != 5 {
+		t.Errorf("Expected a window with a single value, got %+v", stats)
+	}
+
+	// No more statistics are emitted once the signal is shut down.
+	if err := signal.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Minute)
+	select {
+	case stats := <-emitted:
+		t.Errorf("Expected nothing to be emitted after shutting down, got %+v", stats)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestWindowStatsRecvSliding(t *testing.T) {
+	var clock = newFakeClock()
+	var signal = signals.New[int](strconv.Itoa(int(time.Now().UnixNano())))
+	var emitted = make(chan signals.WindowStats, 4)
+
+	signal.Connect(signals.NewWindowStatsRecv[int](time.Minute, func(stats signals.WindowStats) error {
+		emitted <- stats
+		return nil
+	}, signals.WithClock(clock), signals.WithEmitInterval(20*time.Second)))
+
+	var tests = []struct {
+		send  int
+		count int
+		avg   float64
+	}{
+		{send: 1, count: 1, avg: 1},
+		{send: 5, count: 2, avg: 3},
+		{send: 0, count: 2, avg: 3},
+		// The first value falls out of the window.
+		{send: 0, count: 1, avg: 5},
+		{send: 0, count: 0, avg: 0},
+	}
+
+	for i, test := range tests {
+		if test.send != 0 {
+			signal.Send(test.send)
+		}
+		clock.Advance(20 * time.Second)
+
+		var stats = <-emitted
+		if stats.Count != test.count || stats.Avg != test.avg {
+			t.Errorf("Expected tick %d to have count %d and avg %v, got %+v", i, test.count, test.avg, stats)
+		}
+		if stats.End.Sub(stats.Start) != time.Minute {
+			t.Errorf("Expected a window of one minute, got %s to %s", stats.Start, stats.End)
+		}
+	}
+
+	var errEmit = errors.New("emit failed")
+	var hooked = make(chan error, 1)
+	var failing = signals.New[int](
+		strconv.Itoa(int(time.Now().UnixNano())),
+		signals.WithErrorHook(func(signal signals.Signal[int], err error) { hooked <- err }),
+	)
+	var recv = signals.NewWindowStatsRecv[int](time.Minute, func(stats signals.WindowStats) error {
+		return errEmit
+	}, signals.WithClock(clock))
+	failing.Connect(recv)
+	defer recv.(io.Closer).Close()
+
+	if err := failing.Send(1); err != nil {
+		t.Errorf("Expected the send to succeed before the window ends, got %v", err)
+	}
+	clock.Advance(time.Minute)
+	if err := <-hooked; !errors.Is(err, errEmit) {
+		t.Errorf("Expected the error of emit to be passed to the error hook, got %v", err)
+	}
+}
+
+func TestWindowStatsRecvDisconnect(t *testing.T) {
+	var clock = newFakeClock()
+	var signal = signals.New[int](strconv.Itoa(int(time.Now().UnixNano())))
+	var emitted = make(chan signals.WindowStats, 4)
+	var recv = signals.NewWindowStatsRecv[int](time.Minute, func(stats signals.WindowStats) error {
+		emitted <- stats
+		return nil
+	}, signals.WithClock(clock))
+	defer recv.(io.Closer).Close()
+
+	var goroutines = runtime.NumGoroutine()
+	signal.Connect(recv)
+	signal.Send(1)
+
+	// The goroutine emitting the statistics exits once the receiver is disconnected.
+	signal.Disconnect(recv)
+	var deadline = time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > goroutines && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > goroutines {
+		t.Fatalf("Expected the ticks to stop after disconnecting, got %d goroutines instead of %d", n, goroutines)
+	}
+
+	clock.Advance(time.Minute)
+	select {
+	case stats := <-emitted:
+		t.Errorf("Expected nothing to be emitted after disconnecting, got %+v", stats)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	// The ticks start again once the receiver is connected again.
+	signal.Connect(recv)
+	signal.Send(2)
+	clock.Advance(time.Minute)
+	select {
+	case stats := <-emitted:
+		if stats.Count != 1 || stats.Max != 2 {
+			t.Errorf("Expected the value sent after reconnecting, got %+v", stats)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected statistics to be emitted after reconnecting")
+	}
+}
+
+func TestReceiverIDs(t *testing.T) {
+	var noop = func(signal signals.Signal[string], value string) error { return nil }
+	var first = signals.NewRecv(noop)
+	var second = signals.NewRecv(noop)
+
+	if first.ID() == second.ID() {
+		t.Fatalf("Expected receivers to have different IDs, both got %d", first.ID())
+	}
+	if second.ID() != first.ID()+1 {
+		t.Errorf("Expected IDs to be assigned in allocation order, got %d and %d", first.ID(), second.ID())
+	}
+
+	var signal = signals.New[string]("ids")
+	signal.Connect(first, second)
+	signal.Disconnect(second)
+	if signal.Count() != 1 {
+		t.Errorf("Expected only the disconnected receiver to be removed, got %d receivers", signal.Count())
+	}
+}
+
+func TestEveryNRecv(t *testing.T) {
+	var signal = signals.New[int]("every-n")
+	var received []int
+	signal.Connect(signals.NewEveryNRecv(3, func(signal signals.Signal[int], value int) error {
+		received = append(received, value)
+		return nil
+	}))
+
+	for i := 1; i <= 10; i++ {
+		if err := signal.Send(i); err != nil {
+			t.Fatalf("Expected no errors, got %s", err.Error())
+		}
+	}
+
+	if len(received) != 3 || received[0] != 3 || received[1] != 6 || received[2] != 9 {
+		t.Errorf("Expected the callback to be called for the 3rd, 6th and 9th values, got %v", received)
+	}
+}
@@ -0,0 +1,70 @@
+package signals
+
+import (
+	"context"
+	"time"
+)
+
+// Result of calling a single receiver, as part of a SendRecord.
+type RecordedResult struct {
+	// The ID of the receiver.
+	ID uint64
+	// The error returned by the receiver, nil if it succeeded.
+	Err error
+}
+
+// Record of a single send, which can be replayed on another signal.
+type SendRecord[T any] struct {
+	// The name of the signal the value was sent on.
+	Signal string
+	// The value which was sent.
+	Value T
+	// The time the value was sent at.
+	Time time.Time
+	// The result of each receiver, in the order they were called in.
+	Results []RecordedResult
+
+	// Error which prevented the value from being sent at all.
+	err error
+}
+
+// Return the IDs of the receivers, in the order they were called in.
+func (r SendRecord[T]) ReceiverIDs() []uint64 {
+	var ids = make([]uint64, len(r.Results))
+	for i, result := range r.Results {
+		ids[i] = result.ID
+	}
+	return ids
+}
+
+// Return the aggregated error of the send, or nil if it succeeded.
+//
+// This is the same error as would be returned by Send.
+func (r SendRecord[T]) Err() error {
+	return r.err
+}
+
+// Send the recorded value again, on the given signal.
+func (r SendRecord[T]) Replay(s Signal[T]) error {
+	return s.Send(r.Value)
+}
+
+// Send a signal to all receivers, returning a record of the send.
+//
+// The record contains the value, when it was sent and the result of
+// each receiver. It can be replayed on another signal with Replay.
+//
+// The value is sent like it is sent by SendDetailed, the results include
+// the fallback receiver if it was called. The results of all receivers
+// are recorded, even if MaxCollectedErrors was reached.
+func (s *signal[T]) SendRecorded(value T) SendRecord[T] {
+	var record = SendRecord[T]{Signal: s.name, Time: time.Now()}
+	var result = s.sendContext(context.Background(), value, sendHooks[T]{
+		observe: func(receiver Receiver[T], err error) {
+			record.Results = append(record.Results, RecordedResult{ID: receiver.ID(), Err: err})
+		},
+	})
+	record.Value = result.Value
+	record.err = result.Err()
+	return record
+}
@@ -0,0 +1,75 @@
+package signals
+
+import (
+	"bytes"
+	"fmt"
+	"runtime/debug"
+)
+
+// Maximum amount of stack frames captured when a panic in a receiver is recovered.
+//
+// A depth of 0 or less captures the full stack.
+var PanicStackDepth = 32
+
+// Format the message of a PanicError.
+//
+// The function receives the value passed to panic, and the captured stack trace.
+// By default, only the recovered value is included in the message.
+var PanicFormatter = func(recovered any, stack []byte) string {
+	return fmt.Sprintf("receiver panicked: %v", recovered)
+}
+
+// Error for a receiver which panicked, when panics are recovered.
+type PanicError struct {
+	// The value passed to panic.
+	Value any
+	// The stack trace of the goroutine at the time of the panic.
+	Stack []byte
+}
+
+// Return the message of the error, as formatted by PanicFormatter.
+func (e PanicError) Error() string {
+	return PanicFormatter(e.Value, e.Stack)
+}
+
+func (e PanicError) Unwrap() error {
+	return ErrReceiverPanic
+}
+
+// Enable or disable recovering from panics in receivers.
+//
+// When enabled, a receiver which panics returns a PanicError instead,
+// and the remaining receivers are still called.
+func (s *signal[T]) SetRecover(recover bool) {
+	s.recoverPanics.Store(recover)
+}
+
+// Recover from a panic in a receiver, and store it in the error.
+//
+// This must be deferred directly.
+func (s *signal[T]) recoverPanic(err *error) {
+	if r := recover(); r != nil {
+		*err = PanicError{Value: r, Stack: trimStack(debug.Stack(), PanicStackDepth)}
+	}
+}
+
+// Trim a stack trace to the given amount of frames.
+//
+// The stack trace starts with a header line, followed by two lines for each frame.
+func trimStack(stack []byte, depth int) []byte {
+	if depth <= 0 {
+		return stack
+	}
+
+	var lines = 1 + 2*depth
+	for i, b := range stack {
+		if b != '\n' {
+			continue
+		}
+		lines--
+		if lines == 0 {
+			return stack[:i+1]
+		}
+	}
+	return bytes.TrimRight(stack, "\n")
+}
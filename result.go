@@ -0,0 +1,101 @@
+package signals
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Maximum amount of receiver errors collected for a single send.
+//
+// Errors beyond this amount are dropped, and counted in the TruncatedCount
+// of the returned Error. This bounds the memory used when many receivers fail.
+//
+// If zero or negative, all errors are collected.
+var MaxCollectedErrors int
+
+// Error returned by a single receiver.
+type ReceiverError struct {
+	// The ID of the receiver which returned the error.
+	ID uint64
+	// The error returned by the receiver.
+	Err error
+}
+
+func (e ReceiverError) Error() string {
+	return fmt.Sprintf("receiver %d: %s", e.ID, e.Err.Error())
+}
+
+func (e ReceiverError) Unwrap() error {
+	return e.Err
+}
+
+// Detailed result of sending a value to the receivers of a signal.
+type SendResult[T any] struct {
+	// The value which was sent.
+	Value T
+	// The amount of receivers the value was sent to.
+	Total int
+	// The amount of receivers which did not return an error.
+	Succeeded int
+	// The amount of receivers which returned an error.
+	Failed int
+	// The errors returned by the receivers.
+	Errors []ReceiverError
+	// The amount of errors which were dropped, because MaxCollectedErrors was reached.
+	Truncated int
+	// How long it took to send the value to all receivers.
+	Duration time.Duration
+
+	// Error which prevented the value from being sent at all.
+	err error
+	// Amount of receivers which are called in the background.
+	queued int
+	// Receivers which were not called, because the error strategy stopped the send.
+	skipped []Receiver[T]
+}
+
+// Return the aggregated error of the send, or nil if it succeeded.
+//
+// This is the same error as would be returned by Send.
+func (r SendResult[T]) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+	if len(r.Errors) == 0 && r.Truncated == 0 {
+		return nil
+	}
+	var errs = r.receiverErrors()
+	return Error{
+		Val:            fmt.Sprintf("error sending signal to %d receivers", len(errs)+r.Truncated),
+		Errors:         errs,
+		TruncatedCount: r.Truncated,
+	}
+}
+
+// Return the errors returned by the receivers, without the IDs of the receivers.
+func (r SendResult[T]) receiverErrors() []error {
+	var errs = make([]error, len(r.Errors))
+	for i, err := range r.Errors {
+		errs[i] = err.Err
+	}
+	return errs
+}
+
+// Record the error returned by a receiver,
+// or count it as truncated if MaxCollectedErrors was reached.
+func (r *SendResult[T]) fail(id uint64, err error) {
+	if MaxCollectedErrors > 0 && len(r.Errors) >= MaxCollectedErrors {
+		r.Truncated++
+		return
+	}
+	r.Errors = append(r.Errors, ReceiverError{ID: id, Err: err})
+}
+
+// Send a signal to all receivers, returning a detailed result.
+//
+// The result contains the amount of receivers which succeeded or failed,
+// the errors returned by each receiver and how long the send took.
+func (s *signal[T]) SendDetailed(value T) SendResult[T] {
+	return s.sendContext(context.Background(), value, sendHooks[T]{timed: true})
+}
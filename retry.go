@@ -0,0 +1,52 @@
+package signals
+
+import (
+	"context"
+	"time"
+)
+
+// Send a signal to all receivers, retrying the receivers which failed.
+//
+// After each attempt only the receivers which returned an error are retried,
+// receivers which succeeded are not called again. The first attempt is sent like Send,
+// the fallback is only called by the first attempt and is never retried.
+//
+// If the error strategy is StopOnFirstError, the receivers which were not called
+// because an attempt stopped early are called by the next attempt as well.
+//
+// Backoff is called with the number of the attempt which failed, starting at 1,
+// and returns how long to wait before the next attempt. It may be nil.
+//
+// Returns nil once all receivers succeeded, or the errors of the receivers
+// which still failed on the final attempt.
+func (s *signal[T]) SendRetry(value T, attempts int, backoff func(int) time.Duration) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var fallback = s.loadConfig().fallback
+
+	// Track the receivers which failed, the fallback is never retried.
+	var failed []Receiver[T]
+	var hooks = sendHooks[T]{
+		observe: func(receiver Receiver[T], err error) {
+			if err != nil && (fallback == nil || receiver.ID() != fallback.ID()) {
+				failed = append(failed, receiver)
+			}
+		},
+	}
+
+	var generation = s.generation.Load()
+	var result = s.sendContext(context.Background(), value, hooks)
+	for attempt := 1; attempt < attempts && result.err == nil && len(failed) > 0; attempt++ {
+		if backoff != nil {
+			time.Sleep(backoff(attempt))
+		}
+
+		var pending = append(failed, result.skipped...)
+		failed = nil
+		result = s.dispatch(context.Background(), generation, pending, result.Value, hooks)
+	}
+
+	return result.Err()
+}
@@ -0,0 +1,39 @@
+package signals
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Initialize a new receiver which only calls the callback for a sample of the values.
+//
+// The callback is called for approximately rate (between 0.0 and 1.0) of the received values,
+// the other values are skipped without an error.
+//
+// Options can be provided to configure the receiver,
+// WithRand sets the random number generator, for example with a fixed seed in tests.
+func NewSampledRecv[T any](rate float64, cb func(Signal[T], T) error, opts ...RecvOption) Receiver[T] {
+	var (
+		mu  sync.Mutex
+		rng *rand.Rand
+	)
+
+	var r = NewRecv(func(s Signal[T], value T) error {
+		// rand.Rand is not safe for concurrent use.
+		mu.Lock()
+		var sample = rng.Float64() < rate
+		mu.Unlock()
+
+		if !sample {
+			return nil
+		}
+		return cb(s, value)
+	}, opts...)
+
+	rng = r.opts.rng
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return r
+}
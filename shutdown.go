@@ -0,0 +1,57 @@
+package signals
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// Shut down the signal, releasing the resources held by its receivers.
+//
+// All receivers are disconnected from the signal first, so that no new values reach them.
+// Afterwards, receivers implementing Flusher are flushed and receivers implementing
+// io.Closer are closed, in the reverse order they were connected in.
+//
+// If the context is done before all receivers have been released,
+// the remaining receivers are skipped and the error of the context is returned.
+//
+// The name of the signal is released, see OnDuplicateName.
+//
+// Returns the errors of all receivers which could not be flushed or closed.
+func (s *signal[T]) Shutdown(ctx context.Context) error {
+	// Receivers are disconnected in the same critical section they are collected in,
+	// so that a receiver connected concurrently is either released or stays connected.
+	s.mu.Lock()
+	var receivers = s.clear()
+	s.mu.Unlock()
+
+	s.notifyChange(receivers, false)
+	s.releaseName()
+
+	var errs = make([]error, 0)
+	for i := len(receivers) - 1; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			return Error{
+				Val:    fmt.Sprintf("shutdown cancelled, %d of %d receivers were not released", i+1, len(receivers)),
+				Err:    err,
+				Errors: errs,
+			}
+		}
+
+		if flusher, ok := unwrapAs[Flusher](receivers[i]); ok {
+			if err := flusher.Flush(); err != nil {
+				errs = append(errs, ReceiverError{ID: receivers[i].ID(), Err: err})
+			}
+		}
+		if closer, ok := unwrapAs[io.Closer](receivers[i]); ok {
+			if err := closer.Close(); err != nil {
+				errs = append(errs, ReceiverError{ID: receivers[i].ID(), Err: err})
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return e(fmt.Sprintf("error releasing %d receivers", len(errs)), errs...)
+	}
+	return nil
+}
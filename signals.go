@@ -1,9 +1,14 @@
 package signals
 
 import (
+	"context"
 	"fmt"
+	"math/rand"
+	"reflect"
 	"runtime"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Signal interface.
@@ -12,36 +17,232 @@ import (
 type Signal[T any] interface {
 	// Return the name of the signal.
 	Name() string
+	// Set a metadata value on the signal.
+	SetMeta(key, value string)
+	// Return a metadata value of the signal.
+	Meta(key string) (string, bool)
 	// Send a message across the signal's receivers.
+	// Receivers connected during the send do not receive the message.
 	Send(T) error
 	// Send a message across the signal's receivers asynchronously.
 	SendAsync(T) chan error
+	// Send a message across the signal's receivers asynchronously, and wait for them to complete.
+	SendAsyncWait(T) error
+	// Send a message across the signal's receivers asynchronously, using a fixed number of workers.
+	SendAsyncN(workers int, value T) chan error
+	// Send a message across the signal's receivers asynchronously, with sequence numbers.
+	SendAsyncResults(T) chan AsyncResult
+	// Send a message across the signal's receivers, returning a detailed result.
+	SendDetailed(T) SendResult[T]
+	// Send a message across the signal's receivers, returning a record which can be replayed.
+	SendRecorded(T) SendRecord[T]
+	// Send a message as a two-phase commit across the signal's transactional receivers.
+	SendTx(T) error
+	// Send a message across the signal's receivers, until the context is done.
+	SendContext(context.Context, T) error
+	// Send a message, waiting at most the timeout for the receivers to complete.
+	SendWithTimeout(time.Duration, T) error
+	// Send a message synchronously within the time budget, the remaining receivers are called in the background.
+	SendOrQueue(time.Duration, T) error
+	// Send a message, retrying the receivers which failed up to the given amount of attempts.
+	SendRetry(value T, attempts int, backoff func(int) time.Duration) error
+	// Send a message asynchronously, in order with other messages sent with the same key.
+	SendWithKey(string, T) chan error
 	// Connect a list of receivers to the signal.
 	Connect(...Receiver[T]) error
+	// Connect receivers which are not yet connected, returning the amount connected.
+	ConnectUnique(...Receiver[T]) (int, error)
+	// Connect a list of receivers to the signal with the given priority.
+	ConnectPriority(int, ...Receiver[T]) error
+	// Connect a receiver with a weight, used by SendWeighted to select a receiver.
+	ConnectWeighted(int, Receiver[T]) error
+	// Send a message to a single receiver, selected randomly by weight.
+	SendWeighted(T) error
+	// Connect receivers which are called asynchronously, after all other receivers.
+	ConnectAsync(...Receiver[T]) error
+	// Connect a receiver which is only called for values matching the filter.
+	ConnectFilter(func(T) bool, Receiver[T]) error
+	// Connect a list of receivers to the signal until the context is done.
+	ConnectCtx(context.Context, ...Receiver[T]) error
 	// Disconnect a list of receivers from a signal.
 	Disconnect(...Receiver[T])
+	// Disconnect all receivers for which the predicate returns true.
+	DisconnectFunc(func(Receiver[T]) bool) int
+	// Replace a connected receiver with another receiver, at the same position.
+	ReplaceReceiver(old, new Receiver[T]) error
 	// Listen for a signal.
 	Listen(func(Signal[T], T) error) (Receiver[T], error)
+	// Listen for the next value sent on the signal.
+	ListenOnce(func(Signal[T], T) error) (Receiver[T], error)
 	// Clear all receivers for the signal.
 	Clear()
+	// Return the amount of receivers connected to the signal.
+	Count() int
+	// Return a copy of the receivers connected to the signal.
+	Receivers() []Receiver[T]
+	// Check if any receivers are connected to the signal.
+	HasReceivers() bool
+	// Pre-allocate room for the given amount of receivers.
+	Reserve(int)
+	// Enable or disable recovering from panics in receivers.
+	SetRecover(bool)
+	// Set how errors returned by receivers are handled.
+	SetErrorStrategy(ErrorStrategy)
+	// Disconnect all receivers, and release their resources in reverse order.
+	Shutdown(context.Context) error
+	// Add middleware which is called around every receiver.
+	Use(...func(next func(Signal[T], T) error) func(Signal[T], T) error)
+	// Add interceptors which can modify or cancel a value before it is sent.
+	UsePre(...func(name string, value T) (T, bool, error))
+	// Set the function which validates a value before it is sent.
+	SetValidator(func(T) error)
+	// Set the template used to construct values sent with EmitPartial.
+	SetTemplate(tpl T, merge func(tpl, partial T) T)
+	// Merge a partial value into the template, and send it across the signal's receivers.
+	EmitPartial(T) error
+	// Set the receiver which is called when all other receivers fail.
+	SetFallback(Receiver[T])
+	// Return the statistics of the values sent on the signal.
+	Stats() SignalStats
+	// Mirror every value sent on the signal to another signal, asynchronously.
+	Mirror(Signal[T])
+	// Forward values sent on the signal to another signal.
+	ForwardTo(Signal[T], ForwardOptions[T]) (Receiver[T], error)
+	// Freeze the receivers of the signal, so they can be read without locking.
+	Freeze() error
+	// Set the function used to order the receivers before each send.
+	SetOrderFunc(func(a, b Receiver[T]) bool)
+	// Return and clear the value retained by a sticky signal.
+	TakeRetained() (T, bool)
+	// Connect a receiver which is constructed by the factory on the first send.
+	ConnectLazy(factory func() (Receiver[T], error)) (Receiver[T], error)
 }
 
 // Underlying signal struct for the Signal interface.
 //
 // This will be used to send among receivers.
 type signal[T any] struct {
-	name      string        // Name of the signal.
-	receivers []Receiver[T] // List of receivers.
-	mu        *sync.Mutex   // Mutex for locking the signal.
+	name         string        // Name of the signal.
+	receivers    []Receiver[T] // List of receivers.
+	mu           *mutex        // Mutex for locking the signal.
+	maxValueSize int           // Maximum size of a value in bytes, 0 means no limit.
+	sizer        func(T) int   // Function to measure the size of a value.
+
+	// Return ErrNoReceivers when sending without receivers.
+	requireReceivers bool
+
+	// Watchers of receivers connected with ConnectCtx, by the ID of the receiver.
+	watchers map[uint64]watcher
+
+	// Queues for values sent with SendWithKey.
+	keyed keyedQueues[T]
+
+	// Interceptors, validator and fallback, read by sends without locking.
+	config atomic.Pointer[sendConfig[T]]
+
+	// Amount of receivers which declared dependencies on other receivers.
+	dependents int
+	// Amount of receivers which are called asynchronously, after all other receivers.
+	asyncs int
+	// Receivers sorted by their priorities and dependencies, nil if it must be recomputed.
+	order []Receiver[T]
+	// Whether any receivers have a priority.
+	prioritized bool
+	// Reports whether a receiver should be called before another.
+	orderFunc func(a, b Receiver[T]) bool
+	// Used to shuffle the receivers before each send, nil if they are not shuffled.
+	rng *rand.Rand
+
+	// Called with errors which cannot be returned to the caller.
+	errorHook func(Signal[T], error)
+
+	// Template used to construct values sent with EmitPartial.
+	template *template[T]
+
+	// Sequence number of the last asynchronous send.
+	seq atomic.Uint64
+
+	// Reject nil values.
+	rejectNil bool
+
+	// Reject values of a different dynamic type than the first value sent.
+	typeLock bool
+	// The dynamic type of the first value sent, nil if no value has been sent.
+	lockedType atomic.Pointer[reflect.Type]
+
+	// Timeout used by Send, 0 means no timeout.
+	defaultTimeout time.Duration
+
+	// Retain the last value sent, and send it to receivers when they connect.
+	sticky bool
+	// The last value sent on a sticky signal, nil if there is none.
+	retained atomic.Pointer[T]
+
+	// Receivers published by Freeze, nil if the signal is not frozen.
+	frozen atomic.Pointer[[]Receiver[T]]
+
+	// Incremented each time the signal is cleared.
+	generation atomic.Uint64
+
+	// Recover from panics in receivers, returning them as errors.
+	recoverPanics atomic.Bool
+
+	// Registration of the name of the signal, nil if it is not registered, see OnDuplicateName.
+	registered atomic.Pointer[registeredName]
+
+	// How errors returned by receivers are handled, see ErrorStrategy.
+	errorStrategy atomic.Int32
+
+	// Signals which every value is mirrored to.
+	mirrors atomic.Pointer[[]Signal[T]]
+
+	// Middleware called around every receiver.
+	middleware atomic.Pointer[[]func(next func(Signal[T], T) error) func(Signal[T], T) error]
+
+	// Metadata of the signal.
+	meta map[string]string
+
+	// Statistics of the values sent on the signal, only recorded when enabled.
+	stats sendStats
+
+	// Whether the signal was deleted from its pool.
+	deleted atomic.Bool
+	// The signal which replaced this signal in its pool, set by Reconfigure.
+	replacement atomic.Pointer[signal[T]]
+
+	// Links created by ForwardTo between the signals of its pool, nil if it does not belong to a pool.
+	forwards *forwardGraph
+
+	// Extracts values from the context passed to SendContext, attached to its errors.
+	contextExtractor func(ctx context.Context) map[string]string
+
+	// Called when receivers are connected or disconnected, set by the pool.
+	changeHook func(ReceiverChangeEvent)
 }
 
 // Create a new signal.
-func New[T any](name string) Signal[T] {
-	return &signal[T]{
+//
+// Options can be provided to configure the signal.
+//
+// The signal is registered in the tracked signals if TrackSignals is enabled.
+func New[T any](name string, opts ...Option[T]) Signal[T] {
+	var s = newSignal(name, opts...)
+	s.registerName(false)
+	track(s)
+	return s
+}
+
+// Initialize a new signal and apply the options to it.
+func newSignal[T any](name string, opts ...Option[T]) *signal[T] {
+	var s = &signal[T]{
 		name:      name,
 		receivers: make([]Receiver[T], 0),
-		mu:        &sync.Mutex{},
+		mu:        &mutex{},
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 // Return the name of the signal.
@@ -49,80 +250,526 @@ func (s *signal[T]) Name() string {
 	return s.name
 }
 
+// Add interceptors which are run before a value is sent.
+//
+// Interceptors are run in the order they were added,
+// each receives the value returned by the previous interceptor.
+//
+// The value returned by the last interceptor is sent to the receivers.
+//
+// If an interceptor returns false or an error, the value will not be sent
+// and the error (or nil) is returned from the send.
+func (s *signal[T]) UsePre(interceptors ...func(name string, value T) (T, bool, error)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.configure(func(c *sendConfig[T]) {
+		c.pre = append(c.pre[:len(c.pre):len(c.pre)], interceptors...)
+	})
+}
+
+// Set the function which validates a value before it is sent.
+//
+// If the validator returns an error, the value is not sent to any
+// receiver and the error is returned wrapped in an Error.
+//
+// Validation runs after the interceptors, on the value which would be sent.
+//
+// Passing nil removes the validator.
+func (s *signal[T]) SetValidator(validator func(T) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.configure(func(c *sendConfig[T]) {
+		c.validator = validator
+	})
+}
+
+// Configuration which is read by every send.
+//
+// The configuration is never modified in place, it is replaced with a modified copy.
+// This allows sends to read it without locking the signal.
+type sendConfig[T any] struct {
+	// Interceptors run before a value is sent.
+	pre []func(name string, value T) (T, bool, error)
+	// Validates a value before it is sent.
+	validator func(T) error
+	// Called when all receivers returned an error.
+	fallback Receiver[T]
+}
+
+// Replace the configuration of the signal with a modified copy.
+//
+// The signal must be locked when calling this.
+func (s *signal[T]) configure(modify func(c *sendConfig[T])) {
+	var config sendConfig[T]
+	if current := s.config.Load(); current != nil {
+		config = *current
+	}
+	modify(&config)
+	s.config.Store(&config)
+}
+
+// Return the configuration of the signal, without locking.
+func (s *signal[T]) loadConfig() sendConfig[T] {
+	if config := s.config.Load(); config != nil {
+		return *config
+	}
+	return sendConfig[T]{}
+}
+
+// Prepare a value to be sent to the receivers.
+//
+// This runs the interceptors and validates the resulting value.
+//
+// Returns false if the value should not be sent.
+func (s *signal[T]) prepare(value T) (T, bool, error) {
+	if s.deleted.Load() {
+		return value, false, s.deletedError()
+	}
+
+	var config = s.loadConfig()
+	var ok bool
+	var err error
+	for _, interceptor := range config.pre {
+		value, ok, err = interceptor(s.name, value)
+		if !ok || err != nil {
+			return value, false, err
+		}
+	}
+
+	if err = s.validate(value); err != nil {
+		return value, false, err
+	}
+
+	if config.validator != nil {
+		if err = config.validator(value); err != nil {
+			return value, false, Error{Val: fmt.Sprintf("invalid value: %s", err.Error()), Err: err}
+		}
+	}
+
+	s.retain(value)
+	return value, true, nil
+}
+
+// Validate a value before it is sent to the receivers.
+func (s *signal[T]) validate(value T) error {
+	if s.rejectNil && IsNilValue(value) {
+		return wrap(ErrNilValue)
+	}
+
+	if s.typeLock {
+		if err := s.checkType(value); err != nil {
+			return err
+		}
+	}
+
+	if s.maxValueSize > 0 {
+		var size int
+		if s.sizer != nil {
+			size = s.sizer(value)
+		} else {
+			size = DefaultSizer(value)
+		}
+		if size > s.maxValueSize {
+			return e(fmt.Sprintf("value of %d bytes exceeds the maximum size of %d bytes", size, s.maxValueSize))
+		}
+	}
+	return nil
+}
+
 // Send a signal to all receivers.
 //
-// Will error if there are no receivers.
+// Will error if there are no receivers, if the signal requires receivers.
+//
+// Will error if the value is larger than the maximum value size.
 //
 // Returns an error, if any of the receivers return an error.
+//
+// The value is sent to a copy of the receivers, the signal is not locked while
+// the receivers are called. Receivers connected or disconnected during
+// the send will only be affected from the next send onwards.
+//
+// If the signal is cleared during the send, the remaining receivers
+// are not called and an error wrapping ErrSignalCleared is returned.
+//
+// If the signal has a default timeout, this behaves like SendWithTimeout.
 func (s *signal[T]) Send(value T) error {
+	if s.defaultTimeout > 0 {
+		return s.SendWithTimeout(s.defaultTimeout, value)
+	}
+	return s.send(value).Err()
+}
+
+// Prepare the value and send it to a copy of the receivers.
+func (s *signal[T]) send(value T) SendResult[T] {
+	return s.sendContext(context.Background(), value, sendHooks[T]{})
+}
+
+// Hooks into a single send, for the ways of sending which need
+// more than the aggregated result of the send.
+//
+// The zero value does not hook into the send.
+type sendHooks[T any] struct {
+	// Returns the receiver which is called in place of a receiver, or the receiver itself.
+	replace func(receiver Receiver[T]) Receiver[T]
+	// Called with each receiver and the error it returned, after it was called.
+	observe func(receiver Receiver[T], err error)
+	// Receivers which were not started before the deadline are called in the background,
+	// the zero time means there is no deadline.
+	deadline time.Time
+	// Measure how long the send takes, see SendResult.Duration.
+	timed bool
+}
+
+// Prepare the value and send it to a copy of the receivers,
+// until the context is done.
+//
+// The send is only timed if the hooks ask for it, or if statistics are enabled.
+func (s *signal[T]) sendContext(ctx context.Context, value T, hooks sendHooks[T]) SendResult[T] {
+	var stats = s.stats.enabled.Load()
+	if !hooks.timed && !stats {
+		return s.deliver(ctx, value, hooks)
+	}
+
+	var start = time.Now()
+	var result = s.deliver(ctx, value, hooks)
+	result.Duration = time.Since(start)
+	if stats {
+		s.stats.record(start, result.Duration, result.err != nil || result.Failed > 0)
+	}
+	return result
+}
+
+// Send a value to the receivers of the signal, and the fallback receiver if they all fail.
+func (s *signal[T]) deliver(ctx context.Context, value T, hooks sendHooks[T]) SendResult[T] {
+	value, ok, err := s.prepare(value)
+	if !ok {
+		return SendResult[T]{Value: value, err: err}
+	}
+
+	s.mirror(value)
+
+	var generation = s.generation.Load()
+	receivers, err := s.snapshot()
+	if err != nil {
+		return SendResult[T]{Value: value, err: err}
+	}
+
 	// Check if there are any receivers.
-	if len(s.receivers) == 0 {
-		return nil
+	if len(receivers) == 0 {
+		return SendResult[T]{Value: value, err: s.noReceivers()}
 	}
 
-	// Lock the signal so that we can't add
-	// or remove receivers while we're sending.
+	var result = s.dispatch(ctx, generation, receivers, value, hooks)
+
+	// Call the fallback receiver only if every receiver failed,
+	// the send may have stopped before every receiver was called.
+	if result.err != nil || result.queued > 0 || result.Failed < len(receivers) {
+		return result
+	}
+
+	var fallback = s.loadConfig().fallback
+	if fallback != nil {
+		result.Total++
+		if hooks.replace != nil {
+			fallback = hooks.replace(fallback)
+		}
+		var err = s.callContext(ctx, fallback, value)
+		if hooks.observe != nil {
+			hooks.observe(fallback, err)
+		}
+		if err != nil {
+			result.fail(fallback.ID(), err)
+			result.Failed++
+		} else {
+			result.Succeeded++
+		}
+	}
+
+	return result
+}
+
+// Set the receiver which is called when all other receivers fail.
+//
+// After a value is sent, the fallback is only called if every receiver
+// returned an error. The errors of the receivers are still returned,
+// together with the error of the fallback if it fails as well.
+//
+// The fallback is not connected to the signal, and is not counted as one of its receivers.
+//
+// Passing nil removes the fallback.
+func (s *signal[T]) SetFallback(fallback Receiver[T]) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.configure(func(c *sendConfig[T]) {
+		c.fallback = fallback
+	})
+}
 
-	// Send the signal to each receiver.
+// Send the value to each of the receivers.
+//
+// The generation is the generation of the signal when the receivers were copied,
+// if the signal is cleared during the send the remaining receivers are not called
+// and the result will contain an error wrapping ErrSignalCleared, together with the
+// errors returned by the receivers which were already called.
+//
+// If the context is done during the send, the remaining receivers are not called
+// and the result will contain the error of the context, together with the
+// errors returned by the receivers which were already called.
+//
+// If the error strategy is StopOnFirstError, the remaining receivers
+// are not called after the first receiver returns an error.
+//
+// If the hooks have a deadline, receivers which were not started before it
+// are called in the background and their errors are passed to the error hook.
+//
+// Returns the result of the send, containing any errors returned by the receivers.
+func (s *signal[T]) dispatch(ctx context.Context, generation uint64, receivers []Receiver[T], value T, hooks sendHooks[T]) SendResult[T] {
+	var result = SendResult[T]{Value: value, Total: len(receivers)}
+	var done = ctx.Done()
+	var background = ctx == context.Background()
+	var stopOnError = ErrorStrategy(s.errorStrategy.Load()) == StopOnFirstError
+	var recorded = s.recordReceivers()
 	var err error
-	var errs []error = make([]error, 0)
-	for _, receiver := range s.receivers {
-		err = receiver.Receive(s, value)
+	for i, receiver := range receivers {
+		// A context which can never be done is not checked.
+		if done != nil {
+			if err = ctx.Err(); err != nil {
+				result.Total = i
+				result.err = Error{
+					Val:            fmt.Sprintf("send cancelled, %d of %d receivers were not called", len(receivers)-i, len(receivers)),
+					Err:            err,
+					Errors:         result.receiverErrors(),
+					TruncatedCount: result.Truncated,
+				}
+				break
+			}
+		}
+
+		if s.generation.Load() != generation {
+			result.Total = i
+			result.err = Error{
+				Val:            fmt.Sprintf("signal %q was cleared during send, %d of %d receivers were not called", s.name, len(receivers)-i, len(receivers)),
+				Err:            ErrSignalCleared,
+				Errors:         result.receiverErrors(),
+				TruncatedCount: result.Truncated,
+			}
+			break
+		}
+
+		if !hooks.deadline.IsZero() && time.Now().After(hooks.deadline) {
+			var remaining = receivers[i:]
+			go func() {
+				s.handleError(s.dispatch(ctx, generation, remaining, value, sendHooks[T]{}).Err())
+			}()
+			result.Total = i
+			result.queued = len(remaining)
+			break
+		}
+
+		if hooks.replace != nil {
+			receiver = hooks.replace(receiver)
+		}
+		if background {
+			err = s.call(receiver, value)
+		} else {
+			err = s.callContext(ctx, receiver, value)
+		}
+		if hooks.observe != nil {
+			hooks.observe(receiver, err)
+		}
 		if err != nil {
-			errs = append(errs, err)
+			result.fail(receiver.ID(), err)
+			if stopOnError {
+				result.Total = i + 1
+				result.skipped = receivers[i+1:]
+				break
+			}
 		}
 	}
 
-	// Return an error if any of the receivers returned an error.
-	if len(errs) > 0 {
-		return e(fmt.Sprintf("error sending signal to %d receivers", len(errs)), errs...)
+	if recorded != nil {
+		s.assertReceivers(recorded)
 	}
 
-	return nil
+	result.Failed = len(result.Errors) + result.Truncated
+	result.Succeeded = result.Total - result.Failed
+	return result
+}
+
+// Call a single receiver with the value.
+func (s *signal[T]) call(receiver Receiver[T], value T) (err error) {
+	if s.recoverPanics.Load() {
+		defer s.recoverPanic(&err)
+	}
+	// Asynchronous receivers apply the middleware around the receiver they wrap.
+	if s.middleware.Load() == nil || isAsync(receiver) {
+		return receiver.Receive(s, value)
+	}
+	return s.around(receiver.Receive, value)
+}
+
+// Call a single receiver with the value and the context.
+//
+// The context is only passed to receivers implementing ContextReceiver.
+func (s *signal[T]) callContext(ctx context.Context, receiver Receiver[T], value T) (err error) {
+	var ctxReceiver, ok = receiver.(ContextReceiver[T])
+	if !ok {
+		return s.call(receiver, value)
+	}
+	if s.recoverPanics.Load() {
+		defer s.recoverPanic(&err)
+	}
+	// Asynchronous receivers apply the middleware around the receiver they wrap.
+	if isAsync(receiver) {
+		return ctxReceiver.ReceiveContext(ctx, s, value)
+	}
+	return s.around(func(s Signal[T], value T) error {
+		return ctxReceiver.ReceiveContext(ctx, s, value)
+	}, value)
+}
+
+// Return the error for sending without any receivers.
+//
+// Returns nil unless the signal requires receivers.
+func (s *signal[T]) noReceivers() error {
+	if !s.requireReceivers {
+		return nil
+	}
+	return wrap(ErrNoReceivers)
+}
+
+// Return a closed channel, containing the error if it is not nil.
+func closedErrChan(err error) chan error {
+	var errChan = make(chan error, 1)
+	if err != nil {
+		errChan <- err
+	}
+	close(errChan)
+	return errChan
+}
+
+// Pass an error to the error hook, if one is set.
+func (s *signal[T]) handleError(err error) {
+	if s.errorHook != nil && err != nil {
+		s.errorHook(s, err)
+	}
+}
+
+// Return the receivers connected to the signal, in the order they should be called in.
+//
+// The receivers slice is never modified in place, connecting appends to it
+// and disconnecting creates a new slice. This means the returned slice
+// can be used without holding the lock, but it must not be modified.
+//
+// If the signal is frozen, the frozen receivers are returned without locking.
+func (s *signal[T]) snapshot() ([]Receiver[T], error) {
+	if frozen := s.frozen.Load(); frozen != nil {
+		return *frozen, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ordered()
 }
 
 // Send a signal to all receivers asynchronously.
 //
-// Will error if there are no receivers.
+// If there are no receivers, the returned channel will be closed
+// immediately. It will contain ErrNoReceivers if the signal requires receivers.
 //
 // Returns an error, if any of the receivers return an error.
 //
-// This function is not fully tested, and might produce unexpected results.
-//
-// This function also will not check if there are any receivers.
+// The value is sent to a copy of the receivers, the signal is not locked
+// while the receivers are called. Receivers can be connected or disconnected
+// while the send is in progress.
 //
 // Returns a channel which will contain all errors from the receivers.
+// The channel is closed once all receivers have completed.
 func (s *signal[T]) SendAsync(value T) chan error {
-	// Lock the signal so that we can't add
-	// or remove receivers while we're sending.
+	value, ok, err := s.prepare(value)
+	if !ok {
+		return closedErrChan(err)
+	}
 
-	if len(s.receivers) == 0 {
-		return nil
+	receivers, err := s.snapshot()
+	if err != nil {
+		return closedErrChan(err)
+	}
+
+	if len(receivers) == 0 {
+		return closedErrChan(s.noReceivers())
+	}
+
+	// Send the signal to each receiver in its own goroutine.
+	var errChan chan error = make(chan error, len(receivers))
+	var wg sync.WaitGroup
+	wg.Add(len(receivers))
+	for _, receiver := range receivers {
+		go func(receiver Receiver[T]) {
+			defer wg.Done()
+			errChan <- s.call(receiver, value)
+		}(receiver)
 	}
 
-	// Send the signal to each receiver.
-	var errChan chan error = make(chan error, len(s.receivers))
 	go func() {
-		var wg sync.WaitGroup
-		defer wg.Wait()
-		defer close(errChan)
+		wg.Wait()
+		close(errChan)
+	}()
 
-		s.mu.Lock()
-		defer s.mu.Unlock()
+	return errChan
+}
 
-		wg.Add(len(s.receivers))
-		for _, receiver := range s.receivers {
-			// Create a new goroutine for each receiver.
-			go func(receiver Receiver[T], wg *sync.WaitGroup) {
-				defer wg.Done()
-				errChan <- receiver.Receive(s, value)
-			}(receiver, &wg)
-			// Yield the goroutine.
-			runtime.Gosched()
-		}
+// Send a signal to all receivers asynchronously, using a fixed number of workers.
+//
+// This behaves like SendAsync, but instead of starting a goroutine for each receiver,
+// the receivers are divided over the workers. If workers is less than or equal to zero,
+// runtime.NumCPU() workers are used.
+//
+// Returns a channel which will contain all errors from the receivers.
+// The channel is closed once all receivers have completed.
+func (s *signal[T]) SendAsyncN(workers int, value T) chan error {
+	value, ok, err := s.prepare(value)
+	if !ok {
+		return closedErrChan(err)
+	}
+
+	receivers, err := s.snapshot()
+	if err != nil {
+		return closedErrChan(err)
+	}
+
+	if len(receivers) == 0 {
+		return closedErrChan(s.noReceivers())
+	}
+
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(receivers) {
+		workers = len(receivers)
+	}
+
+	// Each worker takes the next receiver, until all receivers have been called.
+	var errChan chan error = make(chan error, len(receivers))
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				var i = int(next.Add(1) - 1)
+				if i >= len(receivers) {
+					return
+				}
+				errChan <- s.call(receivers[i], value)
+			}
+		}()
+	}
+
+	go func() {
 		wg.Wait()
+		close(errChan)
 	}()
 
 	return errChan
@@ -130,51 +777,446 @@ func (s *signal[T]) SendAsync(value T) chan error {
 
 // Connect a receiver to the signal.
 // This will call the receiver's Signal, setting the receiver's signal to this signal.
+//
+// Will error if any of the receivers are nil, none of the receivers will be connected.
+//
+// Will return ErrFrozen if the signal is frozen.
+//
+// If the signal is sticky, the retained value is sent to the new receivers.
+//
+// Receivers are not checked for duplicates, a receiver which is connected
+// twice is called twice for every send. Use ConnectUnique to skip duplicates.
 func (s *signal[T]) Connect(receivers ...Receiver[T]) error {
+	var _, err = s.connect(context.Background(), receivers, false)
+	return err
+}
+
+// Connect receivers which are not yet connected to the signal.
+//
+// Receivers with the same ID as a connected receiver are skipped,
+// returns the amount of receivers which were connected.
+//
+// This behaves like Connect otherwise.
+func (s *signal[T]) ConnectUnique(receivers ...Receiver[T]) (int, error) {
+	return s.connect(context.Background(), receivers, true)
+}
+
+// Connect the receivers, skipping receivers which are already connected if unique is set.
+//
+// If the context can be done, the receivers are only connected if it is not done yet,
+// and they are disconnected once it is. The receivers are connected and watched
+// while the signal is locked, so they cannot be disconnected in between.
+func (s *signal[T]) connect(ctx context.Context, receivers []Receiver[T], unique bool) (int, error) {
+	for _, receiver := range receivers {
+		if receiver == nil {
+			return 0, wrap(ErrNilReceiver)
+		}
+	}
 	s.mu.Lock()
-	defer s.mu.Unlock()
+	if s.deleted.Load() {
+		s.mu.Unlock()
+		return 0, s.deletedError()
+	}
+	if s.isFrozen() {
+		s.mu.Unlock()
+		return 0, wrap(ErrFrozen)
+	}
+	if ctx.Err() != nil {
+		s.mu.Unlock()
+		return 0, nil
+	}
+
+	if unique {
+		var connected = make(map[uint64]struct{}, len(s.receivers)+len(receivers))
+		for _, receiver := range s.receivers {
+			connected[receiver.ID()] = struct{}{}
+		}
+		var filtered = make([]Receiver[T], 0, len(receivers))
+		for _, receiver := range receivers {
+			if _, ok := connected[receiver.ID()]; !ok {
+				connected[receiver.ID()] = struct{}{}
+				filtered = append(filtered, receiver)
+			}
+		}
+		receivers = filtered
+	}
+
 	for _, receiver := range receivers {
 		receiver.Signal(s)
 		s.receivers = append(s.receivers, receiver)
+		if hasDependencies(receiver) {
+			s.dependents++
+		}
+		if isAsync(receiver) {
+			s.asyncs++
+		}
+		if receiverPriority(receiver) != 0 {
+			s.prioritized = true
+		}
+	}
+	s.order = nil
+
+	var watchers = make([]func(), 0)
+	if ctx.Done() != nil {
+		for _, receiver := range receivers {
+			watchers = append(watchers, s.watch(ctx, receiver))
+		}
+	}
+	var retained = s.retained.Load()
+	s.mu.Unlock()
+
+	for _, watch := range watchers {
+		go watch()
+	}
+
+	s.notifyChange(receivers, true)
+	if retained != nil {
+		s.replay(*retained, receivers)
+	}
+	return len(receivers), nil
+}
+
+// Connect receivers to the signal with the given priority.
+//
+// Receivers with a higher priority are called first, receivers connected
+// with Connect have a priority of 0. Receivers with the same priority
+// are called in the order they were connected in.
+//
+// Receivers which can not change their own priority are wrapped,
+// the wrapped receiver shares its ID with the receiver.
+func (s *signal[T]) ConnectPriority(priority int, receivers ...Receiver[T]) error {
+	var prioritized = make([]Receiver[T], len(receivers))
+	for i, receiver := range receivers {
+		switch r := receiver.(type) {
+		case nil:
+			return wrap(ErrNilReceiver)
+		case interface{ SetPriority(int) }:
+			r.SetPriority(priority)
+			prioritized[i] = receiver
+		default:
+			prioritized[i] = &priorityReceiver[T]{Receiver: receiver, priority: priority}
+		}
+	}
+	return s.Connect(prioritized...)
+}
+
+// Connect a receiver to the signal for the lifetime of the context.
+//
+// The receivers will be disconnected once the context is done.
+//
+// If the context is already done, the receivers will not be connected.
+//
+// A receiver which is already watched by an earlier call to ConnectCtx
+// is only disconnected once the context of the last call is done.
+func (s *signal[T]) ConnectCtx(ctx context.Context, receivers ...Receiver[T]) error {
+	var _, err = s.connect(ctx, receivers, false)
+	return err
+}
+
+// Watches a receiver connected with ConnectCtx.
+type watcher struct {
+	ctx context.Context
+	// Closed when the receiver is disconnected by other means.
+	disconnected chan struct{}
+}
+
+// Watch a receiver connected with ConnectCtx, replacing any previous watcher of the receiver.
+//
+// The returned function disconnects the receiver once the context is done,
+// it returns early if the receiver is disconnected by other means.
+//
+// The signal must be locked when calling this,
+// the returned function is meant to be run in its own goroutine.
+func (s *signal[T]) watch(ctx context.Context, receiver Receiver[T]) func() {
+	s.unwatch(receiver)
+	if s.watchers == nil {
+		s.watchers = make(map[uint64]watcher)
+	}
+	var disconnected = make(chan struct{})
+	s.watchers[receiver.ID()] = watcher{ctx: ctx, disconnected: disconnected}
+	return func() {
+		select {
+		case <-ctx.Done():
+			s.Disconnect(receiver)
+		case <-disconnected:
+		}
+	}
+}
+
+// Stop watching a receiver connected with ConnectCtx.
+//
+// The signal must be locked when calling this.
+func (s *signal[T]) unwatch(receiver Receiver[T]) {
+	if w, ok := s.watchers[receiver.ID()]; ok {
+		close(w.disconnected)
+		delete(s.watchers, receiver.ID())
 	}
-	return nil
 }
 
 // Disconnect a receiver from the signal.
+//
+// Calling this without any receivers is a no-op.
+//
+// Disconnecting from a frozen or deleted signal is a no-op as well,
+// ErrFrozen or ErrSignalDeleted is reported to the error hook if one is set.
 func (s *signal[T]) Disconnect(other ...Receiver[T]) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	s.handleError(s.disconnectReceivers(other))
+}
 
-	// Validate if any receivers have been connected.
+// Disconnect the receivers from the signal.
+//
+// Returns an error wrapping ErrFrozen if the signal is frozen,
+// or ErrSignalDeleted if it was deleted from its pool.
+func (s *signal[T]) disconnectReceivers(other []Receiver[T]) error {
 	if len(other) == 0 {
-		panic("did not provide any receivers to disconnect")
-	}
-
-	// Disconnect the receivers.
-	var deleted int
-	for i := range s.receivers {
-		var index = i - deleted
-		for _, o := range other {
-			if s.receivers[index].ID() == o.ID() {
-				o.Signal(nil)
-				s.receivers = append(s.receivers[:index], s.receivers[index+1:]...)
-				deleted++
+		return nil
+	}
+
+	var disconnect = make(map[uint64]struct{}, len(other))
+	for _, o := range other {
+		disconnect[o.ID()] = struct{}{}
+	}
+
+	var _, err = s.disconnect(func(receiver Receiver[T]) bool {
+		var _, ok = disconnect[receiver.ID()]
+		return ok
+	})
+	return err
+}
+
+// Disconnect all receivers for which the predicate returns true.
+//
+// Returns the amount of receivers which were disconnected.
+//
+// Disconnecting from a frozen or deleted signal is a no-op,
+// ErrFrozen or ErrSignalDeleted is reported to the error hook if one is set.
+func (s *signal[T]) DisconnectFunc(predicate func(Receiver[T]) bool) int {
+	var n, err = s.disconnect(predicate)
+	s.handleError(err)
+	return n
+}
+
+// Disconnect the receivers matching the predicate, returning the amount disconnected.
+//
+// Returns an error wrapping ErrFrozen if the signal is frozen,
+// or ErrSignalDeleted if it was deleted from its pool.
+func (s *signal[T]) disconnect(match func(Receiver[T]) bool) (int, error) {
+	s.mu.Lock()
+	if s.deleted.Load() {
+		s.mu.Unlock()
+		return 0, s.deletedError()
+	}
+	if s.isFrozen() {
+		s.mu.Unlock()
+		return 0, wrap(ErrFrozen)
+	}
+
+	// Filter the receivers into a new slice,
+	// the current slice might still be in use by a send.
+	var receivers = make([]Receiver[T], 0, len(s.receivers))
+	var removed = make([]Receiver[T], 0)
+	for _, receiver := range s.receivers {
+		if !match(receiver) {
+			receivers = append(receivers, receiver)
+			continue
+		}
+
+		receiver.Signal(nil)
+		s.unwatch(receiver)
+		if hasDependencies(receiver) {
+			s.dependents--
+		}
+		if isAsync(receiver) {
+			s.asyncs--
+		}
+		removed = append(removed, receiver)
+	}
+
+	s.receivers = receivers
+	if len(removed) > 0 {
+		s.order = nil
+	}
+	s.mu.Unlock()
+
+	s.notifyChange(removed, false)
+	return len(removed), nil
+}
+
+// Replace a connected receiver with another receiver, at the same position.
+//
+// Returns an error wrapping ErrNotConnected if the old receiver is not connected,
+// or ErrFrozen if the signal is frozen.
+func (s *signal[T]) ReplaceReceiver(old, new Receiver[T]) error {
+	if old == nil || new == nil {
+		return wrap(ErrNilReceiver)
+	}
+
+	s.mu.Lock()
+	if s.deleted.Load() {
+		s.mu.Unlock()
+		return s.deletedError()
+	}
+	if s.isFrozen() {
+		s.mu.Unlock()
+		return wrap(ErrFrozen)
+	}
+
+	var index = -1
+	for i, receiver := range s.receivers {
+		if receiver.ID() == old.ID() {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		s.mu.Unlock()
+		return wrap(ErrNotConnected)
+	}
+
+	// Copy the receivers, the current slice might still be in use by a send.
+	var receivers = append(make([]Receiver[T], 0, len(s.receivers)), s.receivers...)
+	var replaced = receivers[index]
+	replaced.Signal(nil)
+	s.unwatch(replaced)
+	if hasDependencies(replaced) {
+		s.dependents--
+	}
+	if isAsync(replaced) {
+		s.asyncs--
+	}
+
+	new.Signal(s)
+	if hasDependencies(new) {
+		s.dependents++
+	}
+	if isAsync(new) {
+		s.asyncs++
+	}
+	if receiverPriority(new) != 0 {
+		s.prioritized = true
+	}
+
+	receivers[index] = new
+	s.receivers = receivers
+	s.order = nil
+	s.mu.Unlock()
+
+	s.notifyChange([]Receiver[T]{replaced}, false)
+	s.notifyChange([]Receiver[T]{new}, true)
+	return nil
+}
+
+// Flush all receivers which implement the Flusher interface.
+//
+// Returns the errors returned by the receivers.
+func (s *signal[T]) flush() []error {
+	var receivers, _ = s.snapshot()
+	var errs = make([]error, 0)
+	for _, receiver := range receivers {
+		if flusher, ok := unwrapAs[Flusher](receiver); ok {
+			if err := flusher.Flush(); err != nil {
+				errs = append(errs, err)
 			}
 		}
 	}
+	return errs
 }
 
 // Clear the signal's receivers.
 // This will disconnect all receivers from the signal.
+//
+// A frozen signal is unfrozen by clearing it.
+//
+// Sends which are in progress stop calling receivers,
+// and return an error wrapping ErrSignalCleared.
+//
+// Clearing a signal which was deleted from its pool is a no-op,
+// ErrSignalDeleted is reported to the error hook if one is set.
 func (s *signal[T]) Clear() {
 	s.mu.Lock()
-	defer s.mu.Unlock()
+	if s.deleted.Load() {
+		s.mu.Unlock()
+		s.handleError(s.deletedError())
+		return
+	}
+	var removed = s.clear()
+	s.mu.Unlock()
+
+	s.notifyChange(removed, false)
+}
 
+// Disconnect all receivers from the signal, and return them.
+//
+// The signal must be locked.
+func (s *signal[T]) clear() []Receiver[T] {
+	var removed = s.receivers
 	for _, receiver := range s.receivers {
-		receiver.Disconnect()
+		receiver.Signal(nil)
+		s.unwatch(receiver)
 	}
 
+	s.dependents = 0
+	s.asyncs = 0
+	s.order = nil
+	s.prioritized = false
+	s.frozen.Store(nil)
+	s.generation.Add(1)
+
 	s.receivers = make([]Receiver[T], 0)
+	return removed
+}
+
+// Return the amount of receivers connected to the signal.
+func (s *signal[T]) Count() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.receivers)
+}
+
+// Return a copy of the receivers connected to the signal, in the order they were connected.
+//
+// Modifying the returned slice does not affect the signal.
+func (s *signal[T]) Receivers() []Receiver[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append(make([]Receiver[T], 0, len(s.receivers)), s.receivers...)
+}
+
+// Check if any receivers are connected to the signal.
+//
+// This can be used to avoid constructing a value, if nobody would receive it.
+func (s *signal[T]) HasReceivers() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.receivers) > 0
+}
+
+// Listen for the next value sent on the signal.
+//
+// This will create a new receiver, and connect it to the signal.
+// The receiver disconnects itself after the function is called once,
+// even if multiple values are sent concurrently.
+func (s *signal[T]) ListenOnce(fn func(Signal[T], T) error) (Receiver[T], error) {
+	var receiver = NewRecv(fn, listenOnce)
+	var err = s.Connect(receiver)
+	return receiver, err
+}
+
+// Pre-allocate room for the given amount of receivers.
+//
+// This reduces allocations when connecting many receivers to the signal at once.
+// Nothing is allocated if the signal already has room for the receivers.
+func (s *signal[T]) Reserve(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n <= cap(s.receivers) {
+		return
+	}
+
+	// The slice might still be in use by a send, copy it instead of growing it in place.
+	var receivers = make([]Receiver[T], len(s.receivers), n)
+	copy(receivers, s.receivers)
+	s.receivers = receivers
 }
 
 // Listen for a signal.
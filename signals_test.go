@@ -1,8 +1,15 @@
 package signals_test
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"expvar"
+	"runtime"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -12,6 +19,8 @@ import (
 var pool = signals.NewPool[string]()
 
 func TestSignals(t *testing.T) {
+	// Sending without receivers only errors if the signal requires receivers.
+	var pool = signals.NewPool[string](signals.RequireReceivers[string]())
 	var signalID = strconv.Itoa(int(time.Now().UnixNano()))
 	var signal = pool.Get(signalID)
 
@@ -161,3 +170,2960 @@ func TestManyRecv(t *testing.T) {
 		t.Errorf("Expected a signal error, got nil")
 	}
 }
+
+func TestMaxValueSize(t *testing.T) {
+	var anyPool = signals.NewPool[any](signals.WithMaxValueSize[any](16))
+	var signal = anyPool.Get(strconv.Itoa(int(time.Now().UnixNano())))
+
+	var received int
+	signal.Listen(func(signal signals.Signal[any], value any) error {
+		received++
+		return nil
+	})
+
+	var err = signal.Send(make([]byte, 32))
+	if err == nil {
+		t.Errorf("Expected an error for an oversized value, got nil")
+	}
+	if received != 0 {
+		t.Errorf("Expected no receivers to be called, got %d", received)
+	}
+
+	err = signal.Send([]byte("small"))
+	if err != nil {
+		t.Errorf("Expected no errors, got %s", err.Error())
+	}
+	if received != 1 {
+		t.Errorf("Expected 1 receiver to be called, got %d", received)
+	}
+}
+
+func TestRangeSnapshot(t *testing.T) {
+	var snapshotPool = signals.NewPool[string]()
+	snapshotPool.Get("signal.1")
+	snapshotPool.Get("signal.2")
+
+	var done = make(chan struct{})
+	var visited int
+	go func() {
+		defer close(done)
+		snapshotPool.RangeSnapshot(func(signal signals.Signal[string]) bool {
+			visited++
+			snapshotPool.Get(signal.Name() + ".child")
+			return true
+		})
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("RangeSnapshot deadlocked when creating a signal inside of the callback")
+	}
+
+	if visited != 2 {
+		t.Errorf("Expected 2 signals to be visited, got %d", visited)
+	}
+
+	var total int
+	snapshotPool.Range(func(signal signals.Signal[string]) bool {
+		total++
+		return true
+	})
+	if total != 4 {
+		t.Errorf("Expected 4 signals in the pool, got %d", total)
+	}
+}
+
+func TestConnectCtx(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())), signals.RequireReceivers[string]())
+	var receiver = signals.NewRecv(func(signal signals.Signal[string], value string) error {
+		return nil
+	})
+
+	var goroutines = runtime.NumGoroutine()
+	var ctx, cancel = context.WithCancel(context.Background())
+	if err := signal.ConnectCtx(ctx, receiver); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+
+	if err := signal.Send("This is a signal message!"); err != nil {
+		t.Errorf("Expected no errors, got %s", err.Error())
+	}
+
+	cancel()
+
+	var deadline = time.Now().Add(time.Second)
+	for !errors.Is(signal.Send("This is a signal message!"), signals.ErrNoReceivers) {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected receiver to be disconnected after the context was cancelled")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	for runtime.NumGoroutine() > goroutines {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d goroutines, got %d", goroutines, runtime.NumGoroutine())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// A receiver disconnected by hand should not leave the watcher running.
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	signal.ConnectCtx(ctx, receiver)
+	receiver.Disconnect()
+	for runtime.NumGoroutine() > goroutines {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d goroutines after disconnecting, got %d", goroutines, runtime.NumGoroutine())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Watching the same receiver again replaces its previous watcher.
+	var other, cancelOther = context.WithCancel(context.Background())
+	defer cancelOther()
+	signal.ConnectCtx(ctx, receiver)
+	signal.ConnectCtx(other, receiver)
+	signal.Disconnect(receiver)
+	for runtime.NumGoroutine() > goroutines {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d goroutines after connecting twice, got %d", goroutines, runtime.NumGoroutine())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// A context which is already done should never connect the receiver.
+	cancel()
+	signal.ConnectCtx(ctx, receiver)
+	if err := signal.Send("This is a signal message!"); !errors.Is(err, signals.ErrNoReceivers) {
+		t.Errorf("Expected ErrNoReceivers for a done context, got %v", err)
+	}
+}
+
+func TestSendWithKey(t *testing.T) {
+	type event struct {
+		Key string
+		N   int
+	}
+
+	var signal = signals.New[event](strconv.Itoa(int(time.Now().UnixNano())))
+	var mu sync.Mutex
+	var order = make(map[string][]int)
+	var bReceived = make(chan struct{})
+
+	signal.Listen(func(signal signals.Signal[event], value event) error {
+		// Key "a" can only continue once key "b" has been received,
+		// this would never happen if the keys were not sent concurrently.
+		if value.Key == "a" && value.N == 0 {
+			select {
+			case <-bReceived:
+			case <-time.After(time.Second):
+				return errors.New("keys were not sent concurrently")
+			}
+		}
+
+		mu.Lock()
+		order[value.Key] = append(order[value.Key], value.N)
+		mu.Unlock()
+
+		if value.Key == "b" && value.N == 0 {
+			close(bReceived)
+		}
+		return nil
+	})
+
+	var results = make([]chan error, 0)
+	for i := 0; i < 3; i++ {
+		results = append(results, signal.SendWithKey("a", event{Key: "a", N: i}))
+		results = append(results, signal.SendWithKey("b", event{Key: "b", N: i}))
+	}
+
+	for _, errChan := range results {
+		if err := <-errChan; err != nil {
+			t.Errorf("Expected no errors, got %s", err.Error())
+		}
+	}
+
+	for _, key := range []string{"a", "b"} {
+		if len(order[key]) != 3 {
+			t.Fatalf("Expected 3 values for key %q, got %d", key, len(order[key]))
+		}
+		for i, n := range order[key] {
+			if n != i {
+				t.Errorf("Expected values for key %q to be in order, got %v", key, order[key])
+				break
+			}
+		}
+	}
+}
+
+func TestUsePre(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var messages = make([]string, 0)
+	var errVeto = errors.New("vetoed")
+
+	signal.Listen(func(signal signals.Signal[string], value string) error {
+		messages = append(messages, value)
+		return nil
+	})
+
+	signal.UsePre(
+		func(name string, value string) (string, bool, error) {
+			return value + " [request-id]", true, nil
+		},
+		func(name string, value string) (string, bool, error) {
+			switch {
+			case strings.HasPrefix(value, "drop"):
+				return value, false, nil
+			case strings.HasPrefix(value, "deny"):
+				return value, false, errVeto
+			}
+			return value, true, nil
+		},
+	)
+
+	if err := signal.Send("hello"); err != nil {
+		t.Errorf("Expected no errors, got %s", err.Error())
+	}
+	if err := signal.Send("drop me"); err != nil {
+		t.Errorf("Expected a dropped send to return nil, got %s", err.Error())
+	}
+	if err := signal.Send("deny me"); !errors.Is(err, errVeto) {
+		t.Errorf("Expected the interceptor error, got %v", err)
+	}
+
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	if messages[0] != "hello [request-id]" {
+		t.Errorf("Expected the value to be modified, got %q", messages[0])
+	}
+}
+
+func TestSendDetailed(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var errFailed = errors.New("failed")
+
+	var failing = signals.NewRecv(func(signal signals.Signal[string], value string) error {
+		return errFailed
+	})
+	signal.Listen(func(signal signals.Signal[string], value string) error { return nil })
+	signal.Connect(failing)
+	signal.Listen(func(signal signals.Signal[string], value string) error { return nil })
+
+	var result = signal.SendDetailed("This is a signal message!")
+	if result.Total != 3 {
+		t.Errorf("Expected 3 receivers in total, got %d", result.Total)
+	}
+	if result.Succeeded != 2 {
+		t.Errorf("Expected 2 receivers to succeed, got %d", result.Succeeded)
+	}
+	if result.Failed != 1 {
+		t.Errorf("Expected 1 receiver to fail, got %d", result.Failed)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].ID != failing.ID() {
+		t.Errorf("Expected the failing receiver's error, got %v", result.Errors)
+	}
+	if result.Duration <= 0 {
+		t.Errorf("Expected a positive duration, got %s", result.Duration)
+	}
+	if result.Value != "This is a signal message!" {
+		t.Errorf("Expected the sent value, got %q", result.Value)
+	}
+
+	var err = result.Err()
+	if !errors.Is(err, errFailed) {
+		t.Errorf("Expected the aggregate to contain the receiver error, got %v", err)
+	}
+	if e, ok := signals.SignalError(err); !ok || e.Len() != 1 {
+		t.Errorf("Expected a signal error with 1 error, got %v", err)
+	}
+
+	signal.Disconnect(failing)
+	if err := signal.SendDetailed("This is a signal message!").Err(); err != nil {
+		t.Errorf("Expected no errors, got %s", err.Error())
+	}
+}
+
+func TestReceiverDependencies(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var order = make([]string, 0)
+	var record = func(name string) func(signals.Signal[string], string) error {
+		return func(signal signals.Signal[string], value string) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	signal.Connect(
+		signals.NewRecv(record("c"), signals.WithKey("c"), signals.WithDependsOn("b")),
+		signals.NewRecv(record("independent")),
+		signals.NewRecv(record("b"), signals.WithKey("b"), signals.WithDependsOn("a")),
+		signals.NewRecv(record("a"), signals.WithKey("a")),
+	)
+
+	if err := signal.Send("This is a signal message!"); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+
+	var expected = []string{"a", "b", "c", "independent"}
+	if strings.Join(order, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected order %v, got %v", expected, order)
+	}
+
+	var cyclic = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	cyclic.Connect(
+		signals.NewRecv(record("x"), signals.WithKey("x"), signals.WithDependsOn("y")),
+		signals.NewRecv(record("y"), signals.WithKey("y"), signals.WithDependsOn("x")),
+	)
+
+	var err = cyclic.Send("This is a signal message!")
+	if !errors.Is(err, signals.ErrDependencyCycle) {
+		t.Fatalf("Expected ErrDependencyCycle, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "x -> y -> x") {
+		t.Errorf("Expected the cycle to be described, got %q", err.Error())
+	}
+}
+
+func TestTrackSignals(t *testing.T) {
+	signals.TrackSignals = true
+	defer func() { signals.TrackSignals = false }()
+
+	var first = signals.New[string]("tracked.1")
+	var second = signals.New[int]("tracked.2")
+	pool.Get("tracked.pool")
+
+	var all = signals.AllSignals()
+	if len(all) != 2 || all[0].Name() != "tracked.1" || all[1].Name() != "tracked.2" {
+		t.Fatalf("Expected the two signals created with New to be tracked, got %v", all)
+	}
+
+	signals.Untrack(first)
+	all = signals.AllSignals()
+	if len(all) != 1 || all[0].Name() != "tracked.2" {
+		t.Fatalf("Expected only the second signal to be tracked, got %v", all)
+	}
+
+	signals.Untrack(second)
+	if all = signals.AllSignals(); len(all) != 0 {
+		t.Errorf("Expected no signals to be tracked, got %v", all)
+	}
+}
+
+func TestSendOrQueue(t *testing.T) {
+	var errSlow = errors.New("slow receiver failed")
+	var hooked = make(chan error, 1)
+	var signal = signals.New[string](
+		strconv.Itoa(int(time.Now().UnixNano())),
+		signals.WithErrorHook(func(signal signals.Signal[string], err error) {
+			hooked <- err
+		}),
+	)
+
+	var fast, slow int32
+	var slowDone = make(chan struct{})
+	signal.Listen(func(signal signals.Signal[string], value string) error {
+		atomic.AddInt32(&fast, 1)
+		return nil
+	})
+	signal.Listen(func(signal signals.Signal[string], value string) error {
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	})
+	signal.Listen(func(signal signals.Signal[string], value string) error {
+		time.Sleep(50 * time.Millisecond)
+		atomic.AddInt32(&slow, 1)
+		close(slowDone)
+		return errSlow
+	})
+
+	if err := signal.SendOrQueue(5*time.Millisecond, "This is a signal message!"); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+
+	if atomic.LoadInt32(&fast) != 1 {
+		t.Errorf("Expected the fast receiver to be called synchronously")
+	}
+	if atomic.LoadInt32(&slow) != 0 {
+		t.Errorf("Expected the caller to return before the slow receiver finished")
+	}
+
+	select {
+	case <-slowDone:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the slow receiver to be called in the background")
+	}
+
+	select {
+	case err := <-hooked:
+		if !errors.Is(err, errSlow) {
+			t.Errorf("Expected the slow receiver's error to be passed to the hook, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the error hook to be called")
+	}
+
+	// The error strategy of the signal applies to the receivers called within the budget.
+	var stopping = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())), signals.StopOnError[string]())
+	var called bool
+	stopping.Listen(func(signal signals.Signal[string], value string) error {
+		return errSlow
+	})
+	stopping.Listen(func(signal signals.Signal[string], value string) error {
+		called = true
+		return nil
+	})
+	if err := stopping.SendOrQueue(time.Second, "This is a signal message!"); !errors.Is(err, errSlow) {
+		t.Errorf("Expected the first receiver's error, got %v", err)
+	}
+	if called {
+		t.Error("Expected the second receiver not to be called after the first error")
+	}
+}
+
+func TestDisconnectNoReceivers(t *testing.T) {
+	var hooked error
+	var signal = signals.New[string](
+		strconv.Itoa(int(time.Now().UnixNano())),
+		signals.WithErrorHook(func(signal signals.Signal[string], err error) {
+			hooked = err
+		}),
+	)
+	signal.Listen(func(signal signals.Signal[string], value string) error {
+		return nil
+	})
+
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("Expected Disconnect() not to panic, got %v", r)
+		}
+	}()
+
+	signal.Disconnect()
+	signal.Disconnect([]signals.Receiver[string]{}...)
+
+	if hooked != nil {
+		t.Errorf("Expected the empty disconnect to be a silent no-op, got %s", hooked.Error())
+	}
+	if signal.Count() != 1 {
+		t.Errorf("Expected the existing receiver to stay connected, got %d receivers", signal.Count())
+	}
+}
+
+func TestSetValidator(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var errEmpty = errors.New("value must not be empty")
+	var received int
+
+	signal.Listen(func(signal signals.Signal[string], value string) error {
+		received++
+		return nil
+	})
+	signal.SetValidator(func(value string) error {
+		if value == "" {
+			return errEmpty
+		}
+		return nil
+	})
+
+	var err = signal.Send("")
+	if !errors.Is(err, errEmpty) {
+		t.Errorf("Expected the validation error, got %v", err)
+	}
+	if _, ok := signals.SignalError(err); !ok {
+		t.Errorf("Expected a signal error, got %T", err)
+	}
+	if received != 0 {
+		t.Errorf("Expected no receivers to be called for an invalid value, got %d", received)
+	}
+
+	if err = signal.Send("valid"); err != nil {
+		t.Errorf("Expected no errors, got %s", err.Error())
+	}
+	if received != 1 {
+		t.Errorf("Expected 1 receiver to be called for a valid value, got %d", received)
+	}
+}
+
+// Receiver which connects another receiver when it is connected to a signal.
+//
+// Connect holds the signal's lock while setting the signal on the receiver,
+// so this attempts to re-acquire a lock the goroutine already holds.
+type reentrantReceiver struct {
+	signals.Receiver[string]
+}
+
+func (r *reentrantReceiver) Signal(signal ...signals.Signal[string]) signals.Signal[string] {
+	if len(signal) > 0 && signal[0] != nil {
+		signal[0].Listen(func(signal signals.Signal[string], value string) error { return nil })
+	}
+	return r.Receiver.Signal(signal...)
+}
+
+func TestDebugLocks(t *testing.T) {
+	signals.DebugLocks = true
+	defer func() { signals.DebugLocks = false }()
+
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var receiver = &reentrantReceiver{
+		Receiver: signals.NewRecv(func(signal signals.Signal[string], value string) error { return nil }),
+	}
+
+	var done = make(chan any)
+	go func() {
+		defer func() { done <- recover() }()
+		signal.Connect(receiver)
+	}()
+
+	select {
+	case r := <-done:
+		var msg, ok = r.(string)
+		if !ok || !strings.Contains(msg, "already holds") {
+			t.Fatalf("Expected a descriptive panic, got %v", r)
+		}
+		if !strings.Contains(msg, "reentrantReceiver") {
+			t.Errorf("Expected the panic to contain the stack trace, got %s", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a panic instead of a deadlock")
+	}
+}
+
+func TestDebugRace(t *testing.T) {
+	signals.DebugRace = true
+	defer func() { signals.DebugRace = false }()
+
+	var signal = signals.New[int](strconv.Itoa(int(time.Now().UnixNano())))
+	signal.Listen(func(s signals.Signal[int], value int) error {
+		if value < 0 {
+			// Connected during the send, the receiver is only called from the next send onwards.
+			s.Listen(func(s signals.Signal[int], value int) error { return nil })
+		}
+		return nil
+	})
+
+	if err := signal.Send(1); err != nil {
+		t.Fatalf("Expected no error, got %s", err.Error())
+	}
+
+	defer func() {
+		var r = recover()
+		if r == nil {
+			t.Fatal("Expected connecting a receiver during the send to panic")
+		}
+		if msg, ok := r.(string); !ok || !strings.Contains(msg, "1 receivers before and 2 after") {
+			t.Errorf("Expected a descriptive panic message, got %v", r)
+		}
+	}()
+	signal.Send(-1)
+}
+
+func TestEmitPartial(t *testing.T) {
+	type event struct {
+		Source string
+		Type   string
+		ID     int
+	}
+
+	var signal = signals.New[event](strconv.Itoa(int(time.Now().UnixNano())))
+	var received = make([]event, 0)
+	signal.Listen(func(signal signals.Signal[event], value event) error {
+		received = append(received, value)
+		return nil
+	})
+
+	if err := signal.EmitPartial(event{ID: 1}); err == nil {
+		t.Error("Expected an error without a template")
+	}
+
+	signal.SetTemplate(event{Source: "billing", Type: "invoice"}, func(tpl, partial event) event {
+		tpl.ID = partial.ID
+		if partial.Type != "" {
+			tpl.Type = partial.Type
+		}
+		return tpl
+	})
+
+	signal.EmitPartial(event{ID: 1})
+	signal.EmitPartial(event{ID: 2, Type: "refund"})
+
+	var expected = []event{
+		{Source: "billing", Type: "invoice", ID: 1},
+		{Source: "billing", Type: "refund", ID: 2},
+	}
+	if len(received) != len(expected) {
+		t.Fatalf("Expected %d events, got %d", len(expected), len(received))
+	}
+	for i := range expected {
+		if received[i] != expected[i] {
+			t.Errorf("Expected %+v, got %+v", expected[i], received[i])
+		}
+	}
+}
+
+func TestSendAsyncResults(t *testing.T) {
+	const sends = 50
+
+	var signal = signals.New[int](strconv.Itoa(int(time.Now().UnixNano())))
+	var first = signals.NewRecv(func(signal signals.Signal[int], value int) error { return nil })
+	var second = signals.NewRecv(func(signal signals.Signal[int], value int) error { return nil })
+	signal.Connect(first, second)
+
+	var mu sync.Mutex
+	var seen = make(map[uint64]int)
+	var wg sync.WaitGroup
+	for i := 0; i < sends; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var seq uint64
+			for result := range signal.SendAsyncResults(i) {
+				if result.Err != nil {
+					t.Errorf("Expected no errors, got %s", result.Err.Error())
+				}
+				if result.ReceiverID != first.ID() && result.ReceiverID != second.ID() {
+					t.Errorf("Expected a known receiver ID, got %d", result.ReceiverID)
+				}
+				if seq != 0 && result.Seq != seq {
+					t.Errorf("Expected all results of a send to share sequence number %d, got %d", seq, result.Seq)
+				}
+				seq = result.Seq
+			}
+			mu.Lock()
+			seen[seq]++
+			mu.Unlock()
+		}(i)
+	}
+	wg.Wait()
+
+	for seq := uint64(1); seq <= sends; seq++ {
+		if seen[seq] != 1 {
+			t.Errorf("Expected sequence number %d to be assigned exactly once, got %d", seq, seen[seq])
+		}
+	}
+
+	// Sends from a single goroutine are assigned increasing sequence numbers.
+	var previous uint64
+	for i := 0; i < 5; i++ {
+		for result := range signal.SendAsyncResults(i) {
+			if result.Seq <= previous {
+				t.Errorf("Expected sequence number greater than %d, got %d", previous, result.Seq)
+			}
+			previous = result.Seq
+			break
+		}
+	}
+}
+
+func TestSetFallback(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var errFailed = errors.New("failed")
+	var spooled = make([]string, 0)
+
+	signal.SetFallback(signals.NewRecv(func(signal signals.Signal[string], value string) error {
+		spooled = append(spooled, value)
+		return nil
+	}))
+
+	var first = signals.NewRecv(func(signal signals.Signal[string], value string) error {
+		return errFailed
+	})
+	var second = signals.NewRecv(func(signal signals.Signal[string], value string) error {
+		if value == "recoverable" {
+			return nil
+		}
+		return errFailed
+	})
+	signal.Connect(first, second)
+
+	var result = signal.SendDetailed("unrecoverable")
+	if !errors.Is(result.Err(), errFailed) {
+		t.Errorf("Expected the receiver errors to be returned, got %v", result.Err())
+	}
+	if result.Total != 3 || result.Failed != 2 || result.Succeeded != 1 {
+		t.Errorf("Expected the fallback to be counted, got %+v", result)
+	}
+	if len(spooled) != 1 || spooled[0] != "unrecoverable" {
+		t.Errorf("Expected the fallback to receive the value, got %v", spooled)
+	}
+
+	signal.Send("recoverable")
+	if len(spooled) != 1 {
+		t.Errorf("Expected the fallback to be skipped when a receiver succeeds, got %v", spooled)
+	}
+}
+
+func TestSetFallbackStopOnError(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	signal.SetErrorStrategy(signals.StopOnFirstError)
+	var errFailed = errors.New("failed")
+
+	var fallbacks int
+	signal.SetFallback(signals.NewRecv(func(signal signals.Signal[string], value string) error {
+		fallbacks++
+		return nil
+	}))
+
+	var calls int
+	var first = signals.NewRecv(func(signal signals.Signal[string], value string) error {
+		return errFailed
+	})
+	var second = signals.NewRecv(func(signal signals.Signal[string], value string) error {
+		calls++
+		return nil
+	})
+	signal.Connect(first, second)
+
+	if err := signal.Send("Hello World!"); !errors.Is(err, errFailed) {
+		t.Errorf("Expected the receiver error to be returned, got %v", err)
+	}
+	if fallbacks != 0 || calls != 0 {
+		t.Errorf("Expected the fallback to be skipped when the send stopped early, got %d fallbacks and %d calls", fallbacks, calls)
+	}
+
+	signal.Disconnect(second)
+	signal.Send("Hello World!")
+	if fallbacks != 1 {
+		t.Errorf("Expected the fallback to be called when every receiver failed, got %d", fallbacks)
+	}
+}
+
+func TestSendAsyncNoReceivers(t *testing.T) {
+	var tests = []struct {
+		name   string
+		opts   []signals.Option[string]
+		errors int
+	}{
+		{name: "Default", errors: 0},
+		{name: "RequireReceivers", opts: []signals.Option[string]{signals.RequireReceivers[string]()}, errors: 1},
+		{name: "AllowNoReceivers", opts: []signals.Option[string]{signals.RequireReceivers[string](), signals.AllowNoReceivers[string]()}, errors: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var signal = signals.New(strconv.Itoa(int(time.Now().UnixNano())), test.opts...)
+			var errChan = signal.SendAsync("This is a signal message!")
+			if errChan == nil {
+				t.Fatal("Expected a channel, got nil")
+			}
+
+			var errs = make([]error, 0)
+			var timeout = time.After(time.Second)
+			for done := false; !done; {
+				select {
+				case err, ok := <-errChan:
+					if !ok {
+						done = true
+						break
+					}
+					errs = append(errs, err)
+				case <-timeout:
+					t.Fatal("Expected the channel to be closed")
+				}
+			}
+
+			if len(errs) != test.errors {
+				t.Fatalf("Expected %d errors, got %d", test.errors, len(errs))
+			}
+			if test.errors > 0 && !errors.Is(errs[0], signals.ErrNoReceivers) {
+				t.Errorf("Expected ErrNoReceivers, got %v", errs[0])
+			}
+			if err := signal.Send("This is a signal message!"); (err != nil) != (test.errors > 0) {
+				t.Errorf("Expected Send to match SendAsync, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRejectNil(t *testing.T) {
+	type payload struct{ ID int }
+
+	var signal = signals.New[any](strconv.Itoa(int(time.Now().UnixNano())), signals.RejectNil[any]())
+	var received int
+	signal.Listen(func(signal signals.Signal[any], value any) error {
+		received++
+		return nil
+	})
+
+	var typedNil *payload
+	if !signals.IsNilValue[any](typedNil) {
+		t.Error("Expected a typed nil to be detected")
+	}
+	if signals.IsNilValue[any](payload{}) {
+		t.Error("Expected a struct value not to be nil")
+	}
+
+	if err := signal.Send(typedNil); !errors.Is(err, signals.ErrNilValue) {
+		t.Errorf("Expected ErrNilValue for a typed nil, got %v", err)
+	}
+	if err := signal.Send(nil); !errors.Is(err, signals.ErrNilValue) {
+		t.Errorf("Expected ErrNilValue for an untyped nil, got %v", err)
+	}
+	if received != 0 {
+		t.Errorf("Expected no receivers to be called for nil values, got %d", received)
+	}
+
+	if err := signal.Send(&payload{ID: 1}); err != nil {
+		t.Errorf("Expected no errors, got %s", err.Error())
+	}
+	if received != 1 {
+		t.Errorf("Expected 1 receiver to be called, got %d", received)
+	}
+}
+
+func TestSendRetry(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var calls [3]int
+	for i := range calls {
+		var i = i
+		signal.Listen(func(signal signals.Signal[string], value string) error {
+			calls[i]++
+			if i > 0 && calls[i] == 1 {
+				return errors.New("transient error")
+			}
+			return nil
+		})
+	}
+
+	var backoffs []int
+	var err = signal.SendRetry("Hello World!", 3, func(attempt int) time.Duration {
+		backoffs = append(backoffs, attempt)
+		return time.Millisecond
+	})
+	if err != nil {
+		t.Errorf("Expected no errors, got %s", err.Error())
+	}
+
+	if calls != [3]int{1, 2, 2} {
+		t.Errorf("Expected only the failed receivers to be retried, got %v", calls)
+	}
+	if len(backoffs) != 1 || backoffs[0] != 1 {
+		t.Errorf("Expected a single backoff after the first attempt, got %v", backoffs)
+	}
+
+	var failing = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var failures int
+	failing.Listen(func(signal signals.Signal[string], value string) error {
+		failures++
+		return errors.New("permanent error")
+	})
+	if err := failing.SendRetry("Hello World!", 3, nil); err == nil {
+		t.Error("Expected an error after exhausting all attempts")
+	}
+	if failures != 3 {
+		t.Errorf("Expected 3 attempts, got %d", failures)
+	}
+
+	// Receivers are retried even if their errors were truncated,
+	// the fallback is not retried.
+	signals.MaxCollectedErrors = 1
+	defer func() { signals.MaxCollectedErrors = 0 }()
+	var retried = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var attempts [2]int
+	for i := range attempts {
+		var i = i
+		retried.Listen(func(signal signals.Signal[string], value string) error {
+			attempts[i]++
+			if attempts[i] == 1 {
+				return errors.New("transient error")
+			}
+			return nil
+		})
+	}
+	var fallbacks int
+	retried.SetFallback(signals.NewRecv(func(signal signals.Signal[string], value string) error {
+		fallbacks++
+		return errors.New("fallback failed")
+	}))
+	if err := retried.SendRetry("Hello World!", 2, nil); err != nil {
+		t.Errorf("Expected no errors once the receivers were retried, got %s", err.Error())
+	}
+	if attempts != [2]int{2, 2} || fallbacks != 1 {
+		t.Errorf("Expected both receivers to be retried and the fallback to be called once, got %v and %d", attempts, fallbacks)
+	}
+}
+
+func TestSendRetryStopOnError(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	signal.SetErrorStrategy(signals.StopOnFirstError)
+	var calls [3]int
+	for i := range calls {
+		var i = i
+		signal.Listen(func(signal signals.Signal[string], value string) error {
+			calls[i]++
+			if i < 2 && calls[i] == 1 {
+				return errors.New("transient error")
+			}
+			return nil
+		})
+	}
+
+	// Each attempt stops at the first failing receiver,
+	// the receivers after it are called by the next attempt.
+	if err := signal.SendRetry("Hello World!", 3, nil); err != nil {
+		t.Errorf("Expected no errors, got %s", err.Error())
+	}
+	if calls != [3]int{2, 2, 1} {
+		t.Errorf("Expected every receiver to be called until it succeeded, got %v", calls)
+	}
+
+	calls = [3]int{}
+	if err := signal.SendRetry("Hello World!", 2, nil); err == nil {
+		t.Error("Expected an error, if the attempts stopped before every receiver was called")
+	}
+}
+
+func TestSignalOnCancel(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var received = make(chan string, 2)
+	signal.Listen(func(signal signals.Signal[string], value string) error {
+		received <- value
+		return nil
+	})
+
+	var ctx, cancel = context.WithCancel(context.Background())
+	var stop = signals.SignalOnCancel(ctx, signal, "shutdown")
+	defer stop()
+
+	cancel()
+	select {
+	case value := <-received:
+		if value != "shutdown" {
+			t.Errorf("Expected %q, got %q", "shutdown", value)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the signal to be sent when the context was cancelled")
+	}
+
+	select {
+	case value := <-received:
+		t.Errorf("Expected the signal to be sent exactly once, got %q again", value)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	signals.SignalOnCancel(ctx, signal, "stopped")()
+	cancel()
+	select {
+	case value := <-received:
+		t.Errorf("Expected no signal after stopping, got %q", value)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestFreeze(t *testing.T) {
+	var hookErrs []error
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())), signals.WithErrorHook(func(signal signals.Signal[string], err error) {
+		hookErrs = append(hookErrs, err)
+	}))
+
+	var received int
+	var receiver, _ = signal.Listen(func(signal signals.Signal[string], value string) error {
+		received++
+		return nil
+	})
+
+	if err := signal.Freeze(); err != nil {
+		t.Fatalf("Expected no errors freezing the signal, got %s", err.Error())
+	}
+
+	if err := signal.Send("Hello World!"); err != nil {
+		t.Errorf("Expected no errors, got %s", err.Error())
+	}
+	if received != 1 {
+		t.Errorf("Expected the frozen receivers to be called, got %d calls", received)
+	}
+
+	if err := signal.Connect(signals.NewRecv(func(signal signals.Signal[string], value string) error { return nil })); !errors.Is(err, signals.ErrFrozen) {
+		t.Errorf("Expected ErrFrozen connecting to a frozen signal, got %v", err)
+	}
+
+	signal.Disconnect(receiver)
+	if len(hookErrs) != 1 || !errors.Is(hookErrs[0], signals.ErrFrozen) {
+		t.Errorf("Expected ErrFrozen to be reported disconnecting from a frozen signal, got %v", hookErrs)
+	}
+	if err := signal.Send("Hello World!"); err != nil || received != 2 {
+		t.Errorf("Expected the receiver to still be connected, got %v and %d calls", err, received)
+	}
+
+	if err := receiver.Disconnect(); !errors.Is(err, signals.ErrFrozen) {
+		t.Errorf("Expected ErrFrozen disconnecting the receiver from a frozen signal, got %v", err)
+	}
+	if receiver.Signal() == nil {
+		t.Error("Expected the receiver to keep its signal")
+	}
+	if err := signal.Send("Hello World!"); err != nil || received != 3 {
+		t.Errorf("Expected the receiver to still be connected, got %v and %d calls", err, received)
+	}
+
+	signal.Clear()
+	if _, err := signal.Listen(func(signal signals.Signal[string], value string) error { return nil }); err != nil {
+		t.Errorf("Expected clearing to unfreeze the signal, got %s", err.Error())
+	}
+}
+
+func BenchmarkFreeze(b *testing.B) {
+	for _, frozen := range []bool{false, true} {
+		b.Run("Frozen="+strconv.FormatBool(frozen), func(b *testing.B) {
+			var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+			connectSignal(8, signal, func(signal signals.Signal[string], value string) error { return nil })
+			if frozen {
+				signal.Freeze()
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				signal.Send("This is a signal message!")
+			}
+		})
+	}
+}
+
+func TestSetOrderFunc(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var order []string
+	for _, key := range []string{"charlie", "alpha", "delta", "bravo"} {
+		var key = key
+		signal.Connect(signals.NewRecv(func(signal signals.Signal[string], value string) error {
+			order = append(order, key)
+			return nil
+		}, signals.WithKey(key)))
+	}
+
+	signal.SetOrderFunc(func(a, b signals.Receiver[string]) bool {
+		return a.(signals.Keyed).Key() < b.(signals.Keyed).Key()
+	})
+	if err := signal.Send("Hello World!"); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	if strings.Join(order, ",") != "alpha,bravo,charlie,delta" {
+		t.Errorf("Expected receivers to be called alphabetically, got %v", order)
+	}
+
+	order = nil
+	signal.SetOrderFunc(nil)
+	signal.Send("Hello World!")
+	if strings.Join(order, ",") != "charlie,alpha,delta,bravo" {
+		t.Errorf("Expected receivers to be called in connection order, got %v", order)
+	}
+
+	// The order function can not move a receiver before a receiver it depends on.
+	order = nil
+	signal.Connect(signals.NewRecv(func(signal signals.Signal[string], value string) error {
+		order = append(order, "aardvark")
+		return nil
+	}, signals.WithKey("aardvark"), signals.WithDependsOn("delta")))
+	signal.SetOrderFunc(func(a, b signals.Receiver[string]) bool {
+		return a.(signals.Keyed).Key() < b.(signals.Keyed).Key()
+	})
+	if err := signal.Send("Hello World!"); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	if strings.Join(order, ",") != "delta,aardvark,alpha,bravo,charlie" {
+		t.Errorf("Expected dependencies to take precedence over the order function, got %v", order)
+	}
+}
+
+func TestTakeRetained(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())), signals.Sticky[string](), signals.AllowNoReceivers[string]())
+	if err := signal.Send("state"); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+
+	var replayed []string
+	signal.Listen(func(signal signals.Signal[string], value string) error {
+		replayed = append(replayed, value)
+		return nil
+	})
+	if strings.Join(replayed, ",") != "state" {
+		t.Errorf("Expected the retained value to be sent on connect, got %v", replayed)
+	}
+
+	var value, ok = signal.TakeRetained()
+	if !ok || value != "state" {
+		t.Errorf("Expected to take the retained value, got %q and %v", value, ok)
+	}
+	if value, ok = signal.TakeRetained(); ok {
+		t.Errorf("Expected no retained value after taking it, got %q", value)
+	}
+
+	var late int
+	signal.Listen(func(signal signals.Signal[string], value string) error {
+		late++
+		return nil
+	})
+	if late != 0 {
+		t.Errorf("Expected no value to be sent on connect after taking it, got %d", late)
+	}
+
+	var plain = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())), signals.AllowNoReceivers[string]())
+	plain.Send("state")
+	if _, ok := plain.TakeRetained(); ok {
+		t.Error("Expected no retained value on a signal which is not sticky")
+	}
+}
+
+func TestConnectLazy(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var built int
+	var received []string
+	var constructed signals.Receiver[string]
+	signal.ConnectLazy(func() (signals.Receiver[string], error) {
+		built++
+		constructed = signals.NewRecv(func(signal signals.Signal[string], value string) error {
+			received = append(received, value)
+			return nil
+		})
+		return constructed, nil
+	})
+
+	if built != 0 {
+		t.Fatalf("Expected the factory not to be called before the first send, got %d calls", built)
+	}
+
+	for _, value := range []string{"first", "second", "third"} {
+		if err := signal.Send(value); err != nil {
+			t.Fatalf("Expected no errors, got %s", err.Error())
+		}
+	}
+
+	if built != 1 {
+		t.Errorf("Expected the factory to be called exactly once, got %d calls", built)
+	}
+	if strings.Join(received, ",") != "first,second,third" {
+		t.Errorf("Expected the constructed receiver to receive every value, got %v", received)
+	}
+
+	if err := constructed.Disconnect(); err != nil {
+		t.Fatalf("Expected no errors disconnecting the constructed receiver, got %s", err.Error())
+	}
+	signal.Send("fourth")
+	if len(received) != 3 {
+		t.Errorf("Expected disconnecting the constructed receiver to disconnect the lazy receiver, got %v", received)
+	}
+	if constructed.Signal() != nil {
+		t.Error("Expected the constructed receiver to no longer be bound to the signal")
+	}
+}
+
+func TestConnectLazyInterfaces(t *testing.T) {
+	type requestIDKey struct{}
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+
+	var ids []string
+	signal.ConnectLazy(func() (signals.Receiver[string], error) {
+		return signals.NewContextRecv(func(ctx context.Context, signal signals.Signal[string], value string) error {
+			var id, _ = ctx.Value(requestIDKey{}).(string)
+			ids = append(ids, id)
+			return nil
+		}), nil
+	})
+	signal.SendContext(context.WithValue(context.Background(), requestIDKey{}, "request-1"), "value")
+	if strings.Join(ids, ",") != "request-1" {
+		t.Errorf("Expected the context to be passed to the constructed receiver, got %v", ids)
+	}
+
+	var closed []string
+	signal.ConnectLazy(func() (signals.Receiver[string], error) {
+		return &resourceReceiver{
+			Receiver: signals.NewRecv(func(signal signals.Signal[string], value string) error { return nil }),
+			name:     "resource",
+			closed:   &closed,
+		}, nil
+	})
+	signal.Send("value")
+	if err := signal.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	if strings.Join(closed, ",") != "resource" {
+		t.Errorf("Expected the constructed receiver to be closed, got %v", closed)
+	}
+}
+
+func TestSendClearedDuringSend(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var started = make(chan struct{})
+	var cleared = make(chan struct{})
+	var errFirst = errors.New("first receiver failed")
+	var after int32
+	signal.Listen(func(signal signals.Signal[string], value string) error {
+		close(started)
+		<-cleared
+		return errFirst
+	})
+	signal.Listen(func(signal signals.Signal[string], value string) error {
+		atomic.AddInt32(&after, 1)
+		return nil
+	})
+
+	var errChan = make(chan error, 1)
+	go func() {
+		errChan <- signal.Send("Hello World!")
+	}()
+
+	<-started
+	signal.Clear()
+	close(cleared)
+
+	var err = <-errChan
+	if !errors.Is(err, signals.ErrSignalCleared) {
+		t.Errorf("Expected ErrSignalCleared, got %v", err)
+	}
+	if !errors.Is(err, errFirst) {
+		t.Errorf("Expected the error to contain the errors of the receivers which already ran, got %v", err)
+	}
+	if atomic.LoadInt32(&after) != 0 {
+		t.Error("Expected the remaining receivers not to be called after clearing")
+	}
+}
+
+func TestDefaultTimeout(t *testing.T) {
+	var hookErrs = make(chan error, 1)
+	var signal = signals.New[string](
+		strconv.Itoa(int(time.Now().UnixNano())),
+		signals.WithDefaultTimeout[string](20*time.Millisecond),
+		signals.WithErrorHook(func(signal signals.Signal[string], err error) {
+			hookErrs <- err
+		}),
+	)
+
+	var release = make(chan struct{})
+	var errSlow = errors.New("slow receiver failed")
+	signal.Listen(func(signal signals.Signal[string], value string) error {
+		<-release
+		return errSlow
+	})
+
+	var start = time.Now()
+	if err := signal.Send("Hello World!"); !errors.Is(err, signals.ErrTimeout) {
+		t.Errorf("Expected ErrTimeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the send to return after the default timeout, took %s", elapsed)
+	}
+
+	close(release)
+	select {
+	case err := <-hookErrs:
+		if !errors.Is(err, errSlow) {
+			t.Errorf("Expected the late error to be passed to the error hook, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected the late error to be passed to the error hook")
+	}
+
+	// A timeout of 0 overrides the default, and waits for the receivers.
+	if err := signal.SendWithTimeout(0, "Hello World!"); !errors.Is(err, errSlow) {
+		t.Errorf("Expected the receiver's error without a timeout, got %v", err)
+	}
+}
+
+func TestShuffleReceivers(t *testing.T) {
+	const sends = 3000
+
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())), signals.ShuffleReceivers[string]())
+	var first = make([]int, 3)
+	var called = -1
+	for i := range first {
+		var i = i
+		signal.Listen(func(signal signals.Signal[string], value string) error {
+			if called == -1 {
+				called = i
+			}
+			return nil
+		})
+	}
+
+	for i := 0; i < sends; i++ {
+		called = -1
+		if err := signal.Send("Hello World!"); err != nil {
+			t.Fatalf("Expected no errors, got %s", err.Error())
+		}
+		first[called]++
+	}
+
+	for i, count := range first {
+		if count < sends/3-300 || count > sends/3+300 {
+			t.Errorf("Expected receiver %d to be called first roughly %d times, got %d (%v)", i, sends/3, count, first)
+		}
+	}
+}
+
+func TestSendRecorded(t *testing.T) {
+	var newSignal = func(received *[]string) (signals.Signal[string], []signals.Receiver[string]) {
+		var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+		var ok, _ = signal.Listen(func(signal signals.Signal[string], value string) error {
+			*received = append(*received, value)
+			return nil
+		})
+		var failing, _ = signal.Listen(func(signal signals.Signal[string], value string) error {
+			return errors.New("receiver failed")
+		})
+		return signal, []signals.Receiver[string]{ok, failing}
+	}
+
+	var original []string
+	var signal, receivers = newSignal(&original)
+	var record = signal.SendRecorded("Hello World!")
+
+	if record.Value != "Hello World!" || record.Signal != signal.Name() || record.Time.IsZero() {
+		t.Errorf("Expected the value, signal and time to be recorded, got %+v", record)
+	}
+	var ids = record.ReceiverIDs()
+	if len(ids) != 2 || ids[0] != receivers[0].ID() || ids[1] != receivers[1].ID() {
+		t.Errorf("Expected the receiver IDs in the order they were called, got %v", ids)
+	}
+	if record.Results[0].Err != nil || record.Results[1].Err == nil {
+		t.Errorf("Expected only the second receiver to fail, got %+v", record.Results)
+	}
+	if record.Err() == nil {
+		t.Error("Expected the record to contain the error of the failed receiver")
+	}
+
+	var replayed []string
+	var fresh, _ = newSignal(&replayed)
+	if err := record.Replay(fresh); err == nil {
+		t.Error("Expected the replay to return the error of the failed receiver")
+	}
+	if strings.Join(replayed, ",") != "Hello World!" {
+		t.Errorf("Expected the recorded value to be replayed, got %v", replayed)
+	}
+
+	// The fallback is recorded when every receiver failed.
+	var failing = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	failing.Listen(func(signal signals.Signal[string], value string) error {
+		return errors.New("receiver failed")
+	})
+	var fallback = signals.NewRecv(func(signal signals.Signal[string], value string) error {
+		return nil
+	})
+	failing.SetFallback(fallback)
+	record = failing.SendRecorded("Hello World!")
+	if len(record.Results) != 2 || record.Results[1].ID != fallback.ID() || record.Results[1].Err != nil {
+		t.Errorf("Expected the fallback to be recorded, got %+v", record.Results)
+	}
+}
+
+func TestOnDuplicateName(t *testing.T) {
+	// Signals created before the hook is set are not taken into account.
+	var early = "early-" + strconv.Itoa(int(time.Now().UnixNano()))
+	signals.New[string](early)
+
+	var duplicates []string
+	signals.OnDuplicateName = func(name string) {
+		duplicates = append(duplicates, name)
+	}
+	defer func() {
+		signals.OnDuplicateName = nil
+	}()
+
+	var name = "duplicate-" + strconv.Itoa(int(time.Now().UnixNano()))
+	var p = signals.NewPool[string]()
+	p.Get(name)
+	p.Get(name)
+	if len(duplicates) != 0 {
+		t.Fatalf("Expected fetching an existing signal not to be reported, got %v", duplicates)
+	}
+
+	signals.New[string](name)
+	if len(duplicates) != 1 || duplicates[0] != name {
+		t.Errorf("Expected the duplicate name %q to be reported, got %v", name, duplicates)
+	}
+
+	var other = "unique-" + strconv.Itoa(int(time.Now().UnixNano()))
+	p.Get(other)
+	p.Delete(other)
+	p.Get(other)
+	if len(duplicates) != 1 {
+		t.Errorf("Expected names of deleted signals to be released, got %v", duplicates)
+	}
+
+	var closed = "closed-" + strconv.Itoa(int(time.Now().UnixNano()))
+	if err := signals.New[string](closed).Shutdown(context.Background()); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	signals.New[string](closed)
+	if len(duplicates) != 1 {
+		t.Errorf("Expected names of signals which were shut down to be released, got %v", duplicates)
+	}
+
+	signals.New[string](early)
+	if len(duplicates) != 1 {
+		t.Errorf("Expected signals created before the hook was set to be ignored, got %v", duplicates)
+	}
+
+	// Reconfiguring a signal does not create another signal with the same name.
+	var reconfigured = "reconfigured-" + strconv.Itoa(int(time.Now().UnixNano()))
+	p.Get(reconfigured)
+	p.Reconfigure(reconfigured)
+	p.Delete(reconfigured)
+	p.Get(reconfigured)
+	if len(duplicates) != 1 {
+		t.Errorf("Expected the name of a reconfigured signal to be released once deleted, got %v", duplicates)
+	}
+
+	// Different pools can have signals with the same name.
+	var shared = "shared-" + strconv.Itoa(int(time.Now().UnixNano()))
+	signals.NewPool[string]().Get(shared)
+	signals.NewPool[string]().Get(shared)
+	if len(duplicates) != 1 {
+		t.Errorf("Expected signals with the same name in different pools not to be reported, got %v", duplicates)
+	}
+	signals.New[string](shared)
+	if len(duplicates) != 2 || duplicates[1] != shared {
+		t.Errorf("Expected the duplicate name %q to be reported, got %v", shared, duplicates)
+	}
+}
+
+func TestDrainErrors(t *testing.T) {
+	var errFailed = errors.New("receiver failed")
+	var ch = make(chan error, 3)
+	ch <- nil
+	ch <- errFailed
+	ch <- nil
+	close(ch)
+
+	var errs, err = signals.DrainErrors(context.Background(), ch)
+	if err != nil {
+		t.Fatalf("Expected no errors draining a closed channel, got %s", err.Error())
+	}
+	if len(errs) != 1 || errs[0] != errFailed {
+		t.Errorf("Expected only the non-nil error to be returned, got %v", errs)
+	}
+
+	var pending = make(chan error)
+	var ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	errs, err = signals.DrainErrors(ctx, pending)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the context error, got %v", err)
+	}
+	if len(errs) != 0 {
+		t.Errorf("Expected no errors, got %v", errs)
+	}
+
+	// The channel is drained in the background after returning early.
+	select {
+	case pending <- errFailed:
+	case <-time.After(time.Second):
+		t.Error("Expected the channel to be drained after returning early")
+	}
+	close(pending)
+}
+
+func TestSendContext(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var errFirst = errors.New("first receiver failed")
+	var started = make(chan struct{})
+	var third int32
+
+	signal.Listen(func(signal signals.Signal[string], value string) error {
+		return errFirst
+	})
+	signal.Connect(signals.NewContextRecv(func(ctx context.Context, signal signals.Signal[string], value string) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+	signal.Listen(func(signal signals.Signal[string], value string) error {
+		atomic.AddInt32(&third, 1)
+		return nil
+	})
+
+	var ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	var err = signal.SendContext(ctx, "Hello World!")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the error to wrap context.Canceled, got %v", err)
+	}
+	if !errors.Is(err, errFirst) {
+		t.Errorf("Expected the error to contain the errors of the receivers which already ran, got %v", err)
+	}
+	if signalErr, ok := signals.SignalError(err); !ok || len(signalErr.Errors) == 0 || signalErr.Errors[0] != errFirst {
+		t.Errorf("Expected the errors of the receivers to be collected as returned, got %v", err)
+	}
+	if atomic.LoadInt32(&third) != 0 {
+		t.Error("Expected the third receiver not to be called after the context was cancelled")
+	}
+}
+
+type resourceReceiver struct {
+	signals.Receiver[string]
+	name   string
+	closed *[]string
+	err    error
+}
+
+func (r *resourceReceiver) Close() error {
+	*r.closed = append(*r.closed, r.name)
+	return r.err
+}
+
+func TestShutdown(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())), signals.RequireReceivers[string]())
+	var closed []string
+	var errDB = errors.New("database close failed")
+	var errFile = errors.New("file close failed")
+	for _, r := range []struct {
+		name string
+		err  error
+	}{{"db", errDB}, {"cache", nil}, {"file", errFile}} {
+		signal.Connect(&resourceReceiver{
+			Receiver: signals.NewRecv(func(signal signals.Signal[string], value string) error { return nil }),
+			name:     r.name,
+			closed:   &closed,
+			err:      r.err,
+		})
+	}
+
+	var err = signal.Shutdown(context.Background())
+	if strings.Join(closed, ",") != "file,cache,db" {
+		t.Errorf("Expected receivers to be closed in reverse order, got %v", closed)
+	}
+	if !errors.Is(err, errDB) || !errors.Is(err, errFile) {
+		t.Errorf("Expected the errors of all receivers to be aggregated, got %v", err)
+	}
+	if err := signal.Send("value"); !errors.Is(err, signals.ErrNoReceivers) {
+		t.Errorf("Expected all receivers to be disconnected, got %v", err)
+	}
+}
+
+func TestSendAsyncConnect(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var release = make(chan struct{})
+	var started = make(chan struct{})
+	signal.Listen(func(signal signals.Signal[string], value string) error {
+		close(started)
+		<-release
+		return nil
+	})
+
+	var errChan = signal.SendAsync("Hello World!")
+	<-started
+
+	var connected = make(chan error, 1)
+	go func() {
+		connected <- signal.Connect(signals.NewRecv(func(signal signals.Signal[string], value string) error { return nil }))
+	}()
+
+	select {
+	case err := <-connected:
+		if err != nil {
+			t.Errorf("Expected no errors connecting, got %s", err.Error())
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected Connect not to block while SendAsync is in progress")
+	}
+
+	close(release)
+	var count int
+	for err := range errChan {
+		if err != nil {
+			t.Errorf("Expected no errors, got %s", err.Error())
+		}
+		count++
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 result from the receivers of the snapshot, got %d", count)
+	}
+}
+
+func TestReserve(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())), signals.WithCapacity[string](2))
+	var received int
+	connectSignal(2, signal, func(signal signals.Signal[string], value string) error {
+		received++
+		return nil
+	})
+	signal.Reserve(4)
+	connectSignal(2, signal, func(signal signals.Signal[string], value string) error {
+		received++
+		return nil
+	})
+
+	if err := signal.Send("Hello World!"); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	if received != 4 {
+		t.Errorf("Expected 4 receivers to be called, got %d", received)
+	}
+}
+
+func BenchmarkReserve(b *testing.B) {
+	const receivers = 32000
+
+	var recvs = make([]signals.Receiver[string], receivers)
+	for i := range recvs {
+		recvs[i] = signals.NewRecv(func(signal signals.Signal[string], value string) error { return nil })
+	}
+
+	for _, reserve := range []bool{false, true} {
+		b.Run("Reserve="+strconv.FormatBool(reserve), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var signal = signals.New[string]("reserve")
+				if reserve {
+					signal.Reserve(receivers)
+				}
+				for _, r := range recvs {
+					signal.Connect(r)
+				}
+			}
+		})
+	}
+}
+
+func TestWithRecover(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())), signals.WithRecover[string]())
+	var called []string
+	signal.Listen(func(signal signals.Signal[string], value string) error {
+		called = append(called, "first")
+		return nil
+	})
+	signal.Listen(func(signal signals.Signal[string], value string) error {
+		panic("something went wrong")
+	})
+	signal.Listen(func(signal signals.Signal[string], value string) error {
+		called = append(called, "third")
+		return nil
+	})
+
+	var err = signal.Send("Hello World!")
+	if strings.Join(called, ",") != "first,third" {
+		t.Errorf("Expected the first and third receivers to be called, got %v", called)
+	}
+	if !errors.Is(err, signals.ErrReceiverPanic) {
+		t.Fatalf("Expected ErrReceiverPanic, got %v", err)
+	}
+
+	var signalErr, ok = signals.SignalError(err)
+	if !ok || signalErr.Len() != 1 {
+		t.Fatalf("Expected a signal error with 1 error, got %v", err)
+	}
+	var panicErr signals.PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("Expected a PanicError, got %v", err)
+	}
+	if panicErr.Value != "something went wrong" || len(panicErr.Stack) == 0 {
+		t.Errorf("Expected the recovered value and a stack trace, got %v", panicErr.Value)
+	}
+
+	signal.SetRecover(false)
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected the panic to propagate after disabling recovery")
+		}
+	}()
+	signal.Send("Hello World!")
+}
+
+func TestReceiverSetPriority(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var order []string
+	var first = signals.NewRecv(func(signal signals.Signal[string], value string) error {
+		order = append(order, "first")
+		return nil
+	})
+	var second = signals.NewRecv(func(signal signals.Signal[string], value string) error {
+		order = append(order, "second")
+		return nil
+	})
+	signal.Connect(first, second)
+
+	signal.Send("Hello World!")
+	if strings.Join(order, ",") != "first,second" {
+		t.Errorf("Expected receivers to be called in connection order, got %v", order)
+	}
+
+	order = nil
+	second.SetPriority(10)
+	signal.Send("Hello World!")
+	if strings.Join(order, ",") != "second,first" {
+		t.Errorf("Expected the receiver with the higher priority to be called first, got %v", order)
+	}
+
+	order = nil
+	first.SetPriority(20)
+	signal.Send("Hello World!")
+	if strings.Join(order, ",") != "first,second" {
+		t.Errorf("Expected the updated priorities to be used, got %v", order)
+	}
+}
+
+func TestCount(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	if signal.Count() != 0 {
+		t.Errorf("Expected 0 receivers, got %d", signal.Count())
+	}
+
+	var first, _ = signal.Listen(func(signal signals.Signal[string], value string) error { return nil })
+	signal.Listen(func(signal signals.Signal[string], value string) error { return nil })
+	if signal.Count() != 2 {
+		t.Errorf("Expected 2 receivers, got %d", signal.Count())
+	}
+
+	first.Disconnect()
+	if signal.Count() != 1 {
+		t.Errorf("Expected 1 receiver after disconnecting, got %d", signal.Count())
+	}
+}
+
+type txReceiver struct {
+	signals.Receiver[string]
+	name       string
+	prepareErr error
+	log        *[]string
+}
+
+func (r *txReceiver) Prepare(signal signals.Signal[string], value string) error {
+	*r.log = append(*r.log, "prepare:"+r.name)
+	return r.prepareErr
+}
+
+func (r *txReceiver) Commit() error {
+	*r.log = append(*r.log, "commit:"+r.name)
+	return nil
+}
+
+func (r *txReceiver) Rollback() error {
+	*r.log = append(*r.log, "rollback:"+r.name)
+	return nil
+}
+
+func TestSendTx(t *testing.T) {
+	var newSignal = func(log *[]string, failing string) signals.Signal[string] {
+		var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+		for _, name := range []string{"a", "b", "c"} {
+			var r = &txReceiver{
+				Receiver: signals.NewRecv(func(signal signals.Signal[string], value string) error { return nil }),
+				name:     name,
+				log:      log,
+			}
+			if name == failing {
+				r.prepareErr = errors.New("prepare failed")
+			}
+			signal.Connect(r)
+		}
+		return signal
+	}
+
+	var log []string
+	var err = newSignal(&log, "b").SendTx("Hello World!")
+	if err == nil {
+		t.Error("Expected an error when a receiver fails to prepare")
+	}
+	if strings.Join(log, ",") != "prepare:a,prepare:b,prepare:c,rollback:a,rollback:c" {
+		t.Errorf("Expected the prepared receivers to roll back and none to commit, got %v", log)
+	}
+
+	log = nil
+	if err := newSignal(&log, "").SendTx("Hello World!"); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	if strings.Join(log, ",") != "prepare:a,prepare:b,prepare:c,commit:a,commit:b,commit:c" {
+		t.Errorf("Expected all receivers to commit, got %v", log)
+	}
+}
+
+func TestListenOnce(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())), signals.AllowNoReceivers[string]())
+	var calls int32
+	signal.ListenOnce(func(signal signals.Signal[string], value string) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+
+	if err := signal.Send("first"); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	if signal.Count() != 0 {
+		t.Errorf("Expected the receiver to disconnect after the first send, got %d receivers", signal.Count())
+	}
+	signal.Send("second")
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("Expected the callback to be called exactly once, got %d", calls)
+	}
+
+	var concurrent int32
+	signal.ListenOnce(func(signal signals.Signal[string], value string) error {
+		atomic.AddInt32(&concurrent, 1)
+		return nil
+	})
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			signal.Send("concurrent")
+		}()
+	}
+	wg.Wait()
+	if atomic.LoadInt32(&concurrent) != 1 {
+		t.Errorf("Expected the callback to be called once across concurrent sends, got %d", concurrent)
+	}
+	if signal.Count() != 0 {
+		t.Errorf("Expected the receiver to be disconnected, got %d receivers", signal.Count())
+	}
+}
+
+func TestPanicFormatter(t *testing.T) {
+	var depth, formatter = signals.PanicStackDepth, signals.PanicFormatter
+	defer func() {
+		signals.PanicStackDepth, signals.PanicFormatter = depth, formatter
+	}()
+
+	signals.PanicStackDepth = 2
+	signals.PanicFormatter = func(recovered any, stack []byte) string {
+		return "custom: " + recovered.(string)
+	}
+
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())), signals.WithRecover[string]())
+	signal.Listen(func(signal signals.Signal[string], value string) error {
+		panic("boom")
+	})
+
+	var err = signal.Send("Hello World!")
+	var panicErr signals.PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("Expected a PanicError, got %v", err)
+	}
+	if panicErr.Error() != "custom: boom" {
+		t.Errorf("Expected the custom formatter to be used, got %q", panicErr.Error())
+	}
+
+	var frames = strings.Count(string(panicErr.Stack), "\n\t")
+	if frames == 0 || frames > 2 {
+		t.Errorf("Expected the stack to contain at most 2 frames, got %d:\n%s", frames, panicErr.Stack)
+	}
+}
+
+func TestConnectPriority(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var order []int
+	var record = func(priority int) func(signals.Signal[string], string) error {
+		return func(signal signals.Signal[string], value string) error {
+			order = append(order, priority)
+			return nil
+		}
+	}
+
+	signal.ConnectPriority(5, signals.NewRecv(record(5)))
+	signal.Connect(signals.NewRecv(record(1), signals.WithPriority(1)))
+	signal.ConnectPriority(10, signals.NewRecv(record(10)))
+	signal.Connect(signals.NewRecv(record(0)))
+
+	if err := signal.Send("Hello World!"); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	if len(order) != 4 || order[0] != 10 || order[1] != 5 || order[2] != 1 || order[3] != 0 {
+		t.Errorf("Expected receivers to be called from the highest to the lowest priority, got %v", order)
+	}
+
+	// Receivers with the same priority keep the order they were connected in.
+	order = nil
+	var tied = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	tied.ConnectPriority(1, signals.NewRecv(record(1)), signals.NewRecv(record(2)))
+	tied.ConnectPriority(1, signals.NewRecv(record(3)))
+	tied.Send("Hello World!")
+	if len(order) != 3 || order[0] != 1 || order[1] != 2 || order[2] != 3 {
+		t.Errorf("Expected ties to keep the order they were connected in, got %v", order)
+	}
+}
+
+// Context receiver which can not set its own priority.
+type plainContextReceiver struct {
+	signals.ContextReceiver[string]
+}
+
+func TestConnectPriorityInterfaces(t *testing.T) {
+	type requestIDKey struct{}
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+
+	var ids []string
+	var receiver = signals.NewContextRecv(func(ctx context.Context, signal signals.Signal[string], value string) error {
+		var id, _ = ctx.Value(requestIDKey{}).(string)
+		ids = append(ids, id)
+		return nil
+	}).(signals.ContextReceiver[string])
+
+	// The receiver can not set its own priority, so it is wrapped.
+	signal.ConnectPriority(1, &plainContextReceiver{receiver})
+	signal.SendContext(context.WithValue(context.Background(), requestIDKey{}, "request-1"), "value")
+	if strings.Join(ids, ",") != "request-1" {
+		t.Errorf("Expected the context to be passed to the prioritized receiver, got %v", ids)
+	}
+
+	var closed []string
+	signal.ConnectPriority(2, &resourceReceiver{
+		Receiver: signals.NewRecv(func(signal signals.Signal[string], value string) error { return nil }),
+		name:     "resource",
+		closed:   &closed,
+	})
+	if err := signal.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	if strings.Join(closed, ",") != "resource" {
+		t.Errorf("Expected the prioritized receiver to be closed, got %v", closed)
+	}
+}
+
+// Receiver with a key and dependencies.
+type dependentReceiver interface {
+	signals.Receiver[string]
+	signals.Keyed
+	signals.Dependent
+}
+
+// Receiver with a key and dependencies, which can not set its own priority.
+type plainReceiver struct {
+	dependentReceiver
+}
+
+func TestConnectPriorityOrder(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var order = make([]string, 0)
+	var record = func(name string) func(signals.Signal[string], string) error {
+		return func(signal signals.Signal[string], value string) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	// The receivers can not set their own priority, so they are wrapped.
+	signal.ConnectPriority(5, &plainReceiver{signals.NewRecv(record("b"), signals.WithKey("b"), signals.WithDependsOn("a"))})
+	signal.ConnectPriority(1, &plainReceiver{signals.NewRecv(record("a"), signals.WithKey("a"))})
+	signal.ConnectPriority(10, &plainReceiver{signals.NewRecv(record("first"))})
+
+	if err := signal.Send("This is a signal message!"); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	if strings.Join(order, ",") != "first,a,b" {
+		t.Errorf("Expected wrapped receivers to keep their key and dependencies, got %v", order)
+	}
+}
+
+func TestMirror(t *testing.T) {
+	var hookErrs = make(chan error, 1)
+	var primary = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())), signals.WithErrorHook(func(signal signals.Signal[string], err error) {
+		hookErrs <- err
+	}))
+	var secondary = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+
+	var release = make(chan struct{})
+	var mirrored = make(chan string, 1)
+	var errAudit = errors.New("audit failed")
+	secondary.Listen(func(signal signals.Signal[string], value string) error {
+		<-release
+		mirrored <- value
+		return errAudit
+	})
+	primary.Listen(func(signal signals.Signal[string], value string) error { return nil })
+	primary.Mirror(secondary)
+
+	var start = time.Now()
+	if err := primary.Send("Hello World!"); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected the primary to return without waiting for the secondary, took %s", elapsed)
+	}
+
+	close(release)
+	select {
+	case value := <-mirrored:
+		if value != "Hello World!" {
+			t.Errorf("Expected the value to be mirrored, got %q", value)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the secondary to receive the value")
+	}
+
+	select {
+	case err := <-hookErrs:
+		if !errors.Is(err, errAudit) {
+			t.Errorf("Expected the secondary's error to be passed to the error hook, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected the secondary's error to be passed to the error hook")
+	}
+}
+
+func TestStopOnError(t *testing.T) {
+	var errFirst = errors.New("first receiver failed")
+	var newSignal = func(opts ...signals.Option[string]) (signals.Signal[string], *[]string) {
+		var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())), opts...)
+		var called []string
+		signal.Listen(func(signal signals.Signal[string], value string) error {
+			called = append(called, "first")
+			return errFirst
+		})
+		signal.Listen(func(signal signals.Signal[string], value string) error {
+			called = append(called, "second")
+			return nil
+		})
+		signal.Listen(func(signal signals.Signal[string], value string) error {
+			called = append(called, "third")
+			return nil
+		})
+		return signal, &called
+	}
+
+	var signal, called = newSignal(signals.StopOnError[string]())
+	var err = signal.Send("Hello World!")
+	if !errors.Is(err, errFirst) {
+		t.Errorf("Expected the first error to be returned, got %v", err)
+	}
+	if _, ok := signals.SignalError(err); !ok {
+		t.Errorf("Expected the error to be wrapped in a signal error, got %T", err)
+	}
+	if strings.Join(*called, ",") != "first" {
+		t.Errorf("Expected the later receivers not to be called, got %v", *called)
+	}
+
+	signal, called = newSignal()
+	signal.Send("Hello World!")
+	if strings.Join(*called, ",") != "first,second,third" {
+		t.Errorf("Expected all receivers to be called by default, got %v", *called)
+	}
+
+	*called = nil
+	signal.SetErrorStrategy(signals.StopOnFirstError)
+	signal.Send("Hello World!")
+	if strings.Join(*called, ",") != "first" {
+		t.Errorf("Expected the later receivers not to be called after setting the strategy, got %v", *called)
+	}
+}
+
+func TestDisconnectMultiple(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())), signals.AllowNoReceivers[string]())
+	var called []string
+	var receivers = make([]signals.Receiver[string], 5)
+	for i := range receivers {
+		var name = strconv.Itoa(i + 1)
+		receivers[i] = signals.NewRecv(func(signal signals.Signal[string], value string) error {
+			called = append(called, name)
+			return nil
+		})
+	}
+	signal.Connect(receivers...)
+
+	signal.Disconnect(receivers[0], receivers[2], receivers[4])
+	if signal.Count() != 2 {
+		t.Errorf("Expected 2 receivers to remain, got %d", signal.Count())
+	}
+
+	signal.Send("Hello World!")
+	if strings.Join(called, ",") != "2,4" {
+		t.Errorf("Expected only receivers 2 and 4 to remain, got %v", called)
+	}
+
+	for i, receiver := range receivers {
+		var connected = receiver.Signal() != nil
+		if connected != (i%2 == 1) {
+			t.Errorf("Expected receiver %d to be connected: %v, got %v", i+1, i%2 == 1, connected)
+		}
+	}
+}
+
+func TestForwardTo(t *testing.T) {
+	var newSignals = func(opts ...signals.Option[int]) (signals.Signal[int], signals.Signal[int], *[]int) {
+		var src = signals.New[int](strconv.Itoa(int(time.Now().UnixNano())), opts...)
+		var dst = signals.New[int](strconv.Itoa(int(time.Now().UnixNano())))
+		var received []int
+		dst.Listen(func(signal signals.Signal[int], value int) error {
+			received = append(received, value)
+			if value < 0 {
+				return errors.New("negative value")
+			}
+			return nil
+		})
+		return src, dst, &received
+	}
+
+	t.Run("Filter", func(t *testing.T) {
+		var src, dst, received = newSignals()
+		src.ForwardTo(dst, signals.ForwardOptions[int]{
+			Filter: func(value int) bool { return value%2 == 0 },
+		})
+		for i := 1; i <= 4; i++ {
+			src.Send(i)
+		}
+		if len(*received) != 2 || (*received)[0] != 2 || (*received)[1] != 4 {
+			t.Errorf("Expected only even values to be forwarded, got %v", *received)
+		}
+	})
+
+	t.Run("Transform", func(t *testing.T) {
+		var src, dst, received = newSignals()
+		var receiver, _ = src.ForwardTo(dst, signals.ForwardOptions[int]{
+			Transform: func(value int) int { return value * 10 },
+		})
+		src.Send(1)
+		receiver.Disconnect()
+		src.Send(2)
+		if len(*received) != 1 || (*received)[0] != 10 {
+			t.Errorf("Expected the transformed value to be forwarded until disconnected, got %v", *received)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		var hookErrs []error
+		var src, dst, _ = newSignals(signals.WithErrorHook(func(signal signals.Signal[int], err error) {
+			hookErrs = append(hookErrs, err)
+		}))
+		var receiver, _ = src.ForwardTo(dst, signals.ForwardOptions[int]{})
+		if err := src.Send(-1); err == nil {
+			t.Error("Expected the destination's error to be propagated")
+		}
+
+		receiver.Disconnect()
+		src.ForwardTo(dst, signals.ForwardOptions[int]{SwallowErrors: true})
+		if err := src.Send(-1); err != nil {
+			t.Errorf("Expected the destination's error to be swallowed, got %s", err.Error())
+		}
+		if len(hookErrs) != 1 {
+			t.Errorf("Expected the swallowed error to be passed to the error hook, got %v", hookErrs)
+		}
+	})
+}
+
+func TestForwardToCycle(t *testing.T) {
+	var newSignal = func() signals.Signal[int] {
+		return signals.New[int](strconv.Itoa(int(time.Now().UnixNano())))
+	}
+	var a, b, c = newSignal(), newSignal(), newSignal()
+
+	var ab, err = a.ForwardTo(b, signals.ForwardOptions[int]{})
+	if err != nil {
+		t.Fatalf("Expected forwarding from a to b to succeed, got %s", err.Error())
+	}
+	if _, err = b.ForwardTo(a, signals.ForwardOptions[int]{}); !errors.Is(err, signals.ErrForwardCycle) {
+		t.Fatalf("Expected forwarding from b to a to be rejected with ErrForwardCycle, got %v", err)
+	}
+	if _, err = a.ForwardTo(a, signals.ForwardOptions[int]{}); !errors.Is(err, signals.ErrForwardCycle) {
+		t.Fatalf("Expected forwarding from a to itself to be rejected with ErrForwardCycle, got %v", err)
+	}
+	if b.Count() != 0 || a.Count() != 1 {
+		t.Fatalf("Expected rejected forwards not to be connected, got %d and %d receivers", a.Count(), b.Count())
+	}
+
+	if _, err = b.ForwardTo(c, signals.ForwardOptions[int]{}); err != nil {
+		t.Fatalf("Expected forwarding from b to c to succeed, got %s", err.Error())
+	}
+	if _, err = c.ForwardTo(a, signals.ForwardOptions[int]{}); !errors.Is(err, signals.ErrForwardCycle) {
+		t.Fatalf("Expected forwarding from c to a to be rejected with ErrForwardCycle, got %v", err)
+	}
+
+	ab.Disconnect()
+	if _, err = c.ForwardTo(a, signals.ForwardOptions[int]{}); err != nil {
+		t.Fatalf("Expected forwarding from c to a to succeed once a no longer forwards to b, got %s", err.Error())
+	}
+}
+
+func TestConnectDuringSend(t *testing.T) {
+	var signal = signals.New[int](strconv.Itoa(int(time.Now().UnixNano())))
+	var received []int
+	var connected bool
+
+	signal.Listen(func(s signals.Signal[int], value int) error {
+		if !connected {
+			connected = true
+			s.Listen(func(s signals.Signal[int], value int) error {
+				received = append(received, value)
+				return nil
+			})
+		}
+		return nil
+	})
+
+	var done = make(chan struct{})
+	go func() {
+		defer close(done)
+		signal.Send(1)
+		signal.Send(2)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected connecting from inside a receiver not to deadlock")
+	}
+
+	if len(received) != 1 || received[0] != 2 {
+		t.Errorf("Expected the new receiver to only receive the next send, got %v", received)
+	}
+}
+
+func TestSendAsyncN(t *testing.T) {
+	var signal = signals.New[int](strconv.Itoa(int(time.Now().UnixNano())))
+	var errFailed = errors.New("failed")
+	for i := 0; i < 1000; i++ {
+		var i = i
+		signal.Listen(func(s signals.Signal[int], value int) error {
+			if i%10 == 0 {
+				return errFailed
+			}
+			return nil
+		})
+	}
+
+	for _, workers := range []int{8, 0} {
+		var results, failed int
+		for err := range signal.SendAsyncN(workers, 1) {
+			results++
+			if errors.Is(err, errFailed) {
+				failed++
+			}
+		}
+		if results != 1000 {
+			t.Errorf("Expected 1000 results with %d workers, got %d", workers, results)
+		}
+		if failed != 100 {
+			t.Errorf("Expected 100 errors with %d workers, got %d", workers, failed)
+		}
+	}
+}
+
+func TestSendAsyncWait(t *testing.T) {
+	var signal = signals.New[int](strconv.Itoa(int(time.Now().UnixNano())))
+	var errFailed = errors.New("failed")
+	for i := 0; i < 5; i++ {
+		var i = i
+		signal.Listen(func(s signals.Signal[int], value int) error {
+			if i < value {
+				return errFailed
+			}
+			return nil
+		})
+	}
+
+	if err := signal.SendAsyncWait(0); err != nil {
+		t.Fatalf("Expected no error, got %s", err.Error())
+	}
+
+	var err = signal.SendAsyncWait(3)
+	var signalErr, ok = signals.SignalError(err)
+	if !ok {
+		t.Fatalf("Expected a signals.Error, got %v", err)
+	}
+	if signalErr.Len() != 3 {
+		t.Errorf("Expected 3 errors, got %d", signalErr.Len())
+	}
+	if !errors.Is(err, errFailed) {
+		t.Errorf("Expected the error to wrap the receivers' errors, got %v", err)
+	}
+
+	var waited = signals.Wait(signal.SendAsync(5))
+	if signalErr, _ = signals.SignalError(waited); signalErr.Len() != 5 {
+		t.Errorf("Expected Wait to return 5 errors, got %d", signalErr.Len())
+	}
+}
+
+func TestStats(t *testing.T) {
+	var name = strconv.Itoa(int(time.Now().UnixNano()))
+	var signal = signals.New[int](name, signals.WithStats[int]())
+	signal.Listen(func(s signals.Signal[int], value int) error {
+		if value < 0 {
+			return errors.New("negative value")
+		}
+		return nil
+	})
+
+	if stats := signal.Stats(); stats.Sends != 0 || !stats.LastSend.IsZero() {
+		t.Fatalf("Expected no sends to be recorded, got %+v", stats)
+	}
+
+	var start = time.Now()
+	signal.Send(1)
+	signal.Send(2)
+	signal.Send(-1)
+
+	var stats = signal.Stats()
+	if stats.Sends != 3 || stats.Errors != 1 || stats.Receivers != 1 {
+		t.Errorf("Expected 3 sends, 1 error and 1 receiver, got %+v", stats)
+	}
+	if stats.LastSend.Before(start) {
+		t.Errorf("Expected the last send to be after %s, got %s", start, stats.LastSend)
+	}
+
+	signals.PublishExpvar("signals_test_"+name, signal)
+	var rendered = expvar.Get("signals_test_" + name).String()
+	var decoded signals.SignalStats
+	if err := json.Unmarshal([]byte(rendered), &decoded); err != nil {
+		t.Fatalf("Expected the expvar to render valid JSON, got %q: %s", rendered, err.Error())
+	}
+	if decoded.Sends != 3 {
+		t.Errorf("Expected the expvar to contain 3 sends, got %d", decoded.Sends)
+	}
+
+	var untimed = signals.New[int](name + "_untimed")
+	untimed.Listen(func(s signals.Signal[int], value int) error { return nil })
+	untimed.Send(1)
+	if stats := untimed.Stats(); stats.Sends != 0 || stats.Receivers != 1 {
+		t.Errorf("Expected no sends to be recorded without WithStats, got %+v", stats)
+	}
+
+	signals.PublishExpvar("signals_test_"+name+"_untimed", untimed)
+	untimed.Send(2)
+	if stats := untimed.Stats(); stats.Sends != 1 {
+		t.Errorf("Expected sends to be recorded once published, got %+v", stats)
+	}
+}
+
+func TestConnectAsync(t *testing.T) {
+	var signal = signals.New[int](strconv.Itoa(int(time.Now().UnixNano())))
+	var release = make(chan struct{})
+	var asyncDone = make(chan struct{})
+	var syncDone bool
+
+	signal.ConnectAsync(signals.NewRecv(func(s signals.Signal[int], value int) error {
+		<-release
+		close(asyncDone)
+		return nil
+	}))
+	signal.Listen(func(s signals.Signal[int], value int) error {
+		time.Sleep(10 * time.Millisecond)
+		syncDone = true
+		return errors.New("sync error")
+	})
+
+	var err = signal.Send(1)
+	if !syncDone {
+		t.Fatal("Expected the synchronous receiver to complete before Send returns")
+	}
+	if err == nil {
+		t.Error("Expected the error of the synchronous receiver to be returned")
+	}
+	select {
+	case <-asyncDone:
+		t.Fatal("Expected Send to return before the asynchronous receiver completes")
+	default:
+	}
+
+	close(release)
+	select {
+	case <-asyncDone:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the asynchronous receiver to run eventually")
+	}
+}
+
+func TestConnectAsyncInterfaces(t *testing.T) {
+	type requestIDKey struct{}
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var calls atomic.Int32
+	signal.Use(func(next func(signals.Signal[string], string) error) func(signals.Signal[string], string) error {
+		return func(s signals.Signal[string], value string) error {
+			calls.Add(1)
+			return next(s, value)
+		}
+	})
+
+	var ids = make(chan string, 1)
+	signal.ConnectAsync(signals.NewContextRecv(func(ctx context.Context, signal signals.Signal[string], value string) error {
+		var id, _ = ctx.Value(requestIDKey{}).(string)
+		ids <- id
+		return nil
+	}))
+	signal.SendContext(context.WithValue(context.Background(), requestIDKey{}, "request-1"), "value")
+	select {
+	case id := <-ids:
+		if id != "request-1" {
+			t.Errorf("Expected the context to be passed to the asynchronous receiver, got %q", id)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the asynchronous receiver to run eventually")
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Expected the middleware to be called once, got %d", n)
+	}
+
+	var closed []string
+	signal.ConnectAsync(&resourceReceiver{
+		Receiver: signals.NewRecv(func(signal signals.Signal[string], value string) error { return nil }),
+		name:     "resource",
+		closed:   &closed,
+	})
+	if err := signal.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	if strings.Join(closed, ",") != "resource" {
+		t.Errorf("Expected the asynchronous receiver to be closed, got %v", closed)
+	}
+}
+
+func TestConnectAsyncDependencies(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var noop = func(signal signals.Signal[string], value string) error { return nil }
+
+	signal.ConnectAsync(signals.NewRecv(noop, signals.WithKey("x"), signals.WithDependsOn("y")))
+	signal.Connect(signals.NewRecv(noop, signals.WithKey("y"), signals.WithDependsOn("x")))
+
+	if err := signal.Send("This is a signal message!"); !errors.Is(err, signals.ErrDependencyCycle) {
+		t.Errorf("Expected asynchronous receivers to keep their key and dependencies, got %v", err)
+	}
+}
+
+func TestConnectAsyncMiddleware(t *testing.T) {
+	var signal = signals.New[int](strconv.Itoa(int(time.Now().UnixNano())))
+	var calls atomic.Int32
+	var done = make(chan struct{})
+	signal.Use(func(next func(signals.Signal[int], int) error) func(signals.Signal[int], int) error {
+		return func(s signals.Signal[int], value int) error {
+			calls.Add(1)
+			return next(s, value)
+		}
+	})
+	signal.ConnectAsync(signals.NewRecv(func(s signals.Signal[int], value int) error {
+		close(done)
+		return nil
+	}))
+
+	if err := signal.Send(1); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the asynchronous receiver to run eventually")
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Expected the middleware to be called once per asynchronous delivery, got %d", n)
+	}
+}
+
+func TestConnectFilter(t *testing.T) {
+	var signal = signals.New[int](strconv.Itoa(int(time.Now().UnixNano())))
+	var even, odd, all []int
+
+	signal.ConnectFilter(func(value int) bool { return value%2 == 0 }, signals.NewRecv(func(s signals.Signal[int], value int) error {
+		even = append(even, value)
+		return nil
+	}))
+	signal.ConnectFilter(func(value int) bool { return value%2 != 0 }, signals.NewRecv(func(s signals.Signal[int], value int) error {
+		odd = append(odd, value)
+		return nil
+	}))
+	signal.ConnectFilter(nil, signals.NewRecv(func(s signals.Signal[int], value int) error {
+		all = append(all, value)
+		return nil
+	}))
+
+	for i := 1; i <= 4; i++ {
+		if err := signal.Send(i); err != nil {
+			t.Fatalf("Expected filtered receivers not to return errors, got %s", err.Error())
+		}
+	}
+
+	if len(even) != 2 || even[0] != 2 || even[1] != 4 {
+		t.Errorf("Expected the even receiver to receive 2 and 4, got %v", even)
+	}
+	if len(odd) != 2 || odd[0] != 1 || odd[1] != 3 {
+		t.Errorf("Expected the odd receiver to receive 1 and 3, got %v", odd)
+	}
+	if len(all) != 4 {
+		t.Errorf("Expected the receiver without a filter to receive every value, got %v", all)
+	}
+}
+
+func TestConnectFilterInterfaces(t *testing.T) {
+	type requestIDKey struct{}
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var skip = func(value string) bool { return value != "skip" }
+
+	var ids []string
+	signal.ConnectFilter(skip, signals.NewContextRecv(func(ctx context.Context, signal signals.Signal[string], value string) error {
+		var id, _ = ctx.Value(requestIDKey{}).(string)
+		ids = append(ids, id)
+		return nil
+	}))
+	var ctx = context.WithValue(context.Background(), requestIDKey{}, "request-1")
+	signal.SendContext(ctx, "value")
+	signal.SendContext(ctx, "skip")
+	if strings.Join(ids, ",") != "request-1" {
+		t.Errorf("Expected the context to be passed through the filter once, got %v", ids)
+	}
+
+	var log []string
+	signal.ConnectFilter(skip, &txReceiver{
+		Receiver: signals.NewRecv(func(signal signals.Signal[string], value string) error { return nil }),
+		name:     "tx",
+		log:      &log,
+	})
+	signal.SendTx("value")
+	signal.SendTx("skip")
+	if strings.Join(log, ",") != "prepare:tx,commit:tx" {
+		t.Errorf("Expected the filtered receiver to take part in the commit once, got %v", log)
+	}
+
+	var closed []string
+	signal.ConnectFilter(skip, &resourceReceiver{
+		Receiver: signals.NewRecv(func(signal signals.Signal[string], value string) error { return nil }),
+		name:     "resource",
+		closed:   &closed,
+	})
+	if err := signal.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	if strings.Join(closed, ",") != "resource" {
+		t.Errorf("Expected the filtered receiver to be closed, got %v", closed)
+	}
+}
+
+func TestConnectFilterOrder(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var order = make([]string, 0)
+	var record = func(name string) func(signals.Signal[string], string) error {
+		return func(signal signals.Signal[string], value string) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+	var all = func(value string) bool { return true }
+
+	signal.ConnectFilter(all, signals.NewRecv(record("b"), signals.WithKey("b"), signals.WithDependsOn("a")))
+	signal.ConnectFilter(all, signals.NewRecv(record("a"), signals.WithKey("a")))
+	signal.ConnectFilter(all, signals.NewRecv(record("first"), signals.WithPriority(10)))
+
+	if err := signal.Send("This is a signal message!"); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	if strings.Join(order, ",") != "first,a,b" {
+		t.Errorf("Expected filtered receivers to keep their priority, key and dependencies, got %v", order)
+	}
+}
+
+func TestMaxCollectedErrors(t *testing.T) {
+	signals.MaxCollectedErrors = 3
+	defer func() { signals.MaxCollectedErrors = 0 }()
+
+	var signal = signals.New[int](strconv.Itoa(int(time.Now().UnixNano())))
+	for i := 0; i < 10; i++ {
+		signal.Listen(func(s signals.Signal[int], value int) error {
+			return errors.New("failed")
+		})
+	}
+
+	var result = signal.SendDetailed(1)
+	if result.Failed != 10 || result.Truncated != 7 {
+		t.Errorf("Expected 10 failed receivers of which 7 truncated, got %d and %d", result.Failed, result.Truncated)
+	}
+
+	var record = signal.SendRecorded(1)
+	if len(record.Results) != 10 {
+		t.Errorf("Expected the results of all 10 receivers to be recorded, got %d", len(record.Results))
+	}
+
+	for _, err := range []error{signal.Send(1), signal.SendAsyncWait(1), record.Err()} {
+		var signalErr, ok = signals.SignalError(err)
+		if !ok {
+			t.Fatalf("Expected a signals.Error, got %v", err)
+		}
+		if signalErr.Len() != 3 {
+			t.Errorf("Expected 3 collected errors, got %d", signalErr.Len())
+		}
+		if signalErr.TruncatedCount != 7 {
+			t.Errorf("Expected 7 truncated errors, got %d", signalErr.TruncatedCount)
+		}
+	}
+}
+
+func TestUse(t *testing.T) {
+	var signal = signals.New[int](strconv.Itoa(int(time.Now().UnixNano())))
+	var errBlocked = errors.New("blocked")
+	var calls []string
+	var mu sync.Mutex
+	var record = func(call string) {
+		mu.Lock()
+		calls = append(calls, call)
+		mu.Unlock()
+	}
+	var wrapped, elapsed atomic.Int64
+
+	signal.Listen(func(s signals.Signal[int], value int) error {
+		record("receiver")
+		return nil
+	})
+	signal.Listen(func(s signals.Signal[int], value int) error {
+		record("receiver")
+		return nil
+	})
+
+	signal.Use(
+		func(next func(signals.Signal[int], int) error) func(signals.Signal[int], int) error {
+			return func(s signals.Signal[int], value int) error {
+				wrapped.Add(1)
+				var start = time.Now()
+				defer func() { elapsed.Add(int64(time.Since(start))) }()
+				return next(s, value)
+			}
+		},
+		func(next func(signals.Signal[int], int) error) func(signals.Signal[int], int) error {
+			return func(s signals.Signal[int], value int) error {
+				record("inner")
+				if value < 0 {
+					return errBlocked
+				}
+				return next(s, value)
+			}
+		},
+	)
+
+	if err := signal.Send(1); err != nil {
+		t.Fatalf("Expected no error, got %s", err.Error())
+	}
+	if wrapped.Load() != 2 {
+		t.Errorf("Expected the middleware to wrap 2 calls, got %d", wrapped.Load())
+	}
+	if strings.Join(calls, ",") != "inner,receiver,inner,receiver" {
+		t.Errorf("Expected the middleware to run in order around each receiver, got %v", calls)
+	}
+
+	var result = signal.SendDetailed(-1)
+	if result.Failed != 2 || !errors.Is(result.Err(), errBlocked) {
+		t.Errorf("Expected the middleware's errors to be collected, got %v", result.Err())
+	}
+
+	if err := signals.Wait(signal.SendAsync(1)); err != nil {
+		t.Fatalf("Expected no error, got %s", err.Error())
+	}
+	if wrapped.Load() != 6 {
+		t.Errorf("Expected the middleware to wrap 6 calls, got %d", wrapped.Load())
+	}
+	if elapsed.Load() <= 0 {
+		t.Error("Expected the middleware to time the calls")
+	}
+}
+
+func TestDisconnectFunc(t *testing.T) {
+	var signal = signals.New[int](strconv.Itoa(int(time.Now().UnixNano())))
+	if n := signal.DisconnectFunc(func(r signals.Receiver[int]) bool { return true }); n != 0 {
+		t.Fatalf("Expected no receivers to be disconnected from an empty signal, got %d", n)
+	}
+
+	var receivers = make([]signals.Receiver[int], 4)
+	for i := range receivers {
+		receivers[i] = signals.NewRecv(func(s signals.Signal[int], value int) error {
+			return nil
+		})
+	}
+	signal.Connect(receivers...)
+
+	var removed = signal.DisconnectFunc(func(r signals.Receiver[int]) bool {
+		return r.ID()%2 == 0
+	})
+	if removed != 2 {
+		t.Fatalf("Expected 2 receivers to be disconnected, got %d", removed)
+	}
+	if signal.Count() != 2 {
+		t.Fatalf("Expected 2 receivers to remain connected, got %d", signal.Count())
+	}
+
+	for _, receiver := range receivers {
+		var connected = receiver.Signal() != nil
+		if connected != (receiver.ID()%2 != 0) {
+			t.Errorf("Expected only receivers with odd IDs to remain connected, receiver %d connected: %t", receiver.ID(), connected)
+		}
+	}
+}
+
+func TestReplaceReceiver(t *testing.T) {
+	var signal = signals.New[int](strconv.Itoa(int(time.Now().UnixNano())))
+	var calls []string
+	var newRecv = func(name string) signals.Receiver[int] {
+		return signals.NewRecv(func(s signals.Signal[int], value int) error {
+			calls = append(calls, name)
+			return nil
+		})
+	}
+
+	var first, middle, last = newRecv("first"), newRecv("middle"), newRecv("last")
+	signal.Connect(first, middle, last)
+
+	var replacement = newRecv("replacement")
+	if err := signal.ReplaceReceiver(middle, replacement); err != nil {
+		t.Fatalf("Expected the receiver to be replaced, got %s", err.Error())
+	}
+
+	signal.Send(1)
+	if strings.Join(calls, ",") != "first,replacement,last" {
+		t.Errorf("Expected the replacement to be called in the old receiver's position, got %v", calls)
+	}
+	if middle.Signal() != nil || replacement.Signal() == nil {
+		t.Error("Expected the signal to be moved from the old receiver to the replacement")
+	}
+
+	if err := signal.ReplaceReceiver(middle, newRecv("other")); !errors.Is(err, signals.ErrNotConnected) {
+		t.Errorf("Expected replacing a disconnected receiver to return ErrNotConnected, got %v", err)
+	}
+	var p = signals.NewPool[int]()
+	var deleted = p.Get("deleted")
+	var connected = newRecv("connected")
+	deleted.Connect(connected)
+	p.Delete("deleted")
+	if err := deleted.ReplaceReceiver(connected, newRecv("other")); !errors.Is(err, signals.ErrSignalDeleted) {
+		t.Errorf("Expected replacing a receiver of a deleted signal to return ErrSignalDeleted, got %v", err)
+	}
+}
+
+func TestConnectUnique(t *testing.T) {
+	var signal = signals.New[int](strconv.Itoa(int(time.Now().UnixNano())))
+	var received int
+	var receiver = signals.NewRecv(func(s signals.Signal[int], value int) error {
+		received++
+		return nil
+	})
+
+	if n, err := signal.ConnectUnique(receiver, receiver); err != nil || n != 1 {
+		t.Fatalf("Expected 1 receiver to be connected, got %d (%v)", n, err)
+	}
+	if n, err := signal.ConnectUnique(receiver); err != nil || n != 0 {
+		t.Fatalf("Expected the duplicate receiver to be skipped, got %d (%v)", n, err)
+	}
+
+	signal.Send(1)
+	if received != 1 {
+		t.Errorf("Expected a single send to deliver exactly one message, got %d", received)
+	}
+}
+
+func TestTicker(t *testing.T) {
+	var clock = newFakeClock()
+	var signal = signals.New[int](strconv.Itoa(int(time.Now().UnixNano())))
+	var sent = make(chan int, 10)
+	signal.Listen(func(s signals.Signal[int], value int) error {
+		sent <- value
+		return nil
+	})
+
+	var ticks int
+	var stop = signals.Ticker[int](signal, time.Second, func() int {
+		ticks++
+		return ticks
+	}, signals.WithClock(clock))
+
+	clock.Advance(500 * time.Millisecond)
+	select {
+	case value := <-sent:
+		t.Fatalf("Expected nothing to be sent before the first tick, got %d", value)
+	default:
+	}
+
+	// The next tick is registered before sending,
+	// so it is always registered once the value has been received.
+	clock.Advance(500 * time.Millisecond)
+	for i := 1; i <= 3; i++ {
+		select {
+		case value := <-sent:
+			if value != i {
+				t.Errorf("Expected tick %d to send %d, got %d", i, i, value)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected tick %d to be sent", i)
+		}
+		if i < 3 {
+			clock.Advance(time.Second)
+		}
+	}
+
+	stop()
+	stop()
+
+	clock.Advance(time.Hour)
+	select {
+	case value := <-sent:
+		t.Errorf("Expected no sends after stopping, got %d", value)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a non-positive interval to panic")
+		}
+	}()
+	signals.Ticker[int](signal, 0, func() int { return 0 })
+}
+
+func TestSendWeighted(t *testing.T) {
+	var signal = signals.New[int](strconv.Itoa(int(time.Now().UnixNano())))
+	var light, heavy int
+	signal.ConnectWeighted(1, signals.NewRecv(func(s signals.Signal[int], value int) error {
+		light++
+		return nil
+	}))
+	signal.ConnectWeighted(3, signals.NewRecv(func(s signals.Signal[int], value int) error {
+		heavy++
+		return nil
+	}))
+
+	if err := signal.ConnectWeighted(0, signals.NewRecv(func(s signals.Signal[int], value int) error {
+		return nil
+	})); err == nil {
+		t.Error("Expected a weight of zero to be rejected")
+	}
+
+	for i := 0; i < 10000; i++ {
+		if err := signal.SendWeighted(i); err != nil {
+			t.Fatalf("Expected no error, got %s", err.Error())
+		}
+	}
+
+	if light+heavy != 10000 {
+		t.Fatalf("Expected every send to deliver to exactly one receiver, got %d deliveries", light+heavy)
+	}
+	var ratio = float64(heavy) / float64(light)
+	if ratio < 2.5 || ratio > 3.5 {
+		t.Errorf("Expected a delivery ratio of about 1:3, got %d:%d", light, heavy)
+	}
+}
+
+func TestConnectWeightedInterfaces(t *testing.T) {
+	type requestIDKey struct{}
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+
+	var ids []string
+	signal.ConnectWeighted(2, signals.NewContextRecv(func(ctx context.Context, signal signals.Signal[string], value string) error {
+		var id, _ = ctx.Value(requestIDKey{}).(string)
+		ids = append(ids, id)
+		return nil
+	}))
+	signal.SendContext(context.WithValue(context.Background(), requestIDKey{}, "request-1"), "value")
+	if strings.Join(ids, ",") != "request-1" {
+		t.Errorf("Expected the context to be passed to the weighted receiver, got %v", ids)
+	}
+
+	var closed []string
+	signal.ConnectWeighted(2, &resourceReceiver{
+		Receiver: signals.NewRecv(func(signal signals.Signal[string], value string) error { return nil }),
+		name:     "resource",
+		closed:   &closed,
+	})
+	if err := signal.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	if strings.Join(closed, ",") != "resource" {
+		t.Errorf("Expected the weighted receiver to be closed, got %v", closed)
+	}
+}
+
+type heavyReceiver struct {
+	signals.Receiver[int]
+}
+
+func (r *heavyReceiver) Weight() int {
+	return 3
+}
+
+func TestSendWeightedWrapped(t *testing.T) {
+	var signal = signals.New[int](strconv.Itoa(int(time.Now().UnixNano())))
+	var light, heavy int
+	signal.Connect(signals.NewRecv(func(s signals.Signal[int], value int) error {
+		light++
+		return nil
+	}))
+
+	// The weight of the receiver is kept when it is wrapped.
+	signal.ConnectPriority(1, &heavyReceiver{Receiver: signals.NewRecv(func(s signals.Signal[int], value int) error {
+		heavy++
+		return nil
+	})})
+
+	for i := 0; i < 10000; i++ {
+		if err := signal.SendWeighted(i); err != nil {
+			t.Fatalf("Expected no error, got %s", err.Error())
+		}
+	}
+
+	var ratio = float64(heavy) / float64(light)
+	if ratio < 2.5 || ratio > 3.5 {
+		t.Errorf("Expected a delivery ratio of about 1:3, got %d:%d", light, heavy)
+	}
+}
+
+func TestConnectWeightedOrder(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var order = make([]string, 0)
+	var record = func(name string) func(signals.Signal[string], string) error {
+		return func(signal signals.Signal[string], value string) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	signal.ConnectWeighted(1, signals.NewRecv(record("b"), signals.WithKey("b"), signals.WithDependsOn("a")))
+	signal.ConnectWeighted(1, signals.NewRecv(record("a"), signals.WithKey("a")))
+	signal.ConnectWeighted(1, signals.NewRecv(record("first"), signals.WithPriority(10)))
+
+	if err := signal.Send("This is a signal message!"); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	if strings.Join(order, ",") != "first,a,b" {
+		t.Errorf("Expected weighted receivers to keep their priority, key and dependencies, got %v", order)
+	}
+}
+
+func TestReceivers(t *testing.T) {
+	var signal = signals.New[int](strconv.Itoa(int(time.Now().UnixNano())))
+	var received int
+	for i := 0; i < 3; i++ {
+		signal.Listen(func(s signals.Signal[int], value int) error {
+			received++
+			return nil
+		})
+	}
+
+	var receivers = signal.Receivers()
+	if len(receivers) != signal.Count() {
+		t.Fatalf("Expected %d receivers, got %d", signal.Count(), len(receivers))
+	}
+
+	receivers[0] = signals.NewRecv(func(s signals.Signal[int], value int) error {
+		t.Error("Expected a receiver set on the copy not to be called")
+		return nil
+	})
+
+	signal.Send(1)
+	if received != 3 {
+		t.Errorf("Expected all 3 receivers to be called, got %d", received)
+	}
+}
+
+func TestSendContextErrorContext(t *testing.T) {
+	type requestIDKey struct{}
+	var signal = signals.New[int](
+		strconv.Itoa(int(time.Now().UnixNano())),
+		signals.WithContextExtractor[int](func(ctx context.Context) map[string]string {
+			var id, _ = ctx.Value(requestIDKey{}).(string)
+			return map[string]string{"request_id": id}
+		}),
+	)
+	var errFailed = errors.New("failed")
+	signal.Listen(func(s signals.Signal[int], value int) error {
+		if value < 0 {
+			return errFailed
+		}
+		return nil
+	})
+
+	var ctx = context.WithValue(context.Background(), requestIDKey{}, "req-42")
+	if err := signal.SendContext(ctx, 1); err != nil {
+		t.Fatalf("Expected no error, got %s", err.Error())
+	}
+
+	var err = signal.SendContext(ctx, -1)
+	var signalErr, ok = signals.SignalError(err)
+	if !ok {
+		t.Fatalf("Expected a signals.Error, got %v", err)
+	}
+	if signalErr.Context["request_id"] != "req-42" {
+		t.Errorf("Expected the error to carry the request ID, got %v", signalErr.Context)
+	}
+	if !errors.Is(err, errFailed) {
+		t.Errorf("Expected the error to wrap the receiver's error, got %v", err)
+	}
+}
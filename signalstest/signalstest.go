@@ -0,0 +1,133 @@
+// Package signalstest provides helpers for testing code which uses signals.
+//
+// The helpers only use the public API of the signals package,
+// they connect receivers with Listen and remove them with Disconnect.
+package signalstest
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Nigel2392/go-signals"
+)
+
+// Expectation about the values received by a signal.
+type Expectation[T any] struct {
+	t      testing.TB
+	signal signals.Signal[T]
+}
+
+// Start an expectation about the values received by the signal.
+func Expect[T any](t testing.TB, signal signals.Signal[T]) *Expectation[T] {
+	return &Expectation[T]{t: t, signal: signal}
+}
+
+// Expect the signal to receive a value equal to v.
+//
+// Values are compared with reflect.DeepEqual. The expectation is registered
+// immediately, values sent before Within is called are taken into account.
+func (e *Expectation[T]) ReceivesValue(v T) *ValueExpectation[T] {
+	var (
+		received = make(chan struct{})
+		once     sync.Once
+	)
+	var receiver, err = e.signal.Listen(func(s signals.Signal[T], value T) error {
+		if reflect.DeepEqual(value, v) {
+			once.Do(func() { close(received) })
+		}
+		return nil
+	})
+	if err != nil {
+		e.t.Helper()
+		e.t.Errorf("signalstest: could not listen to signal %q: %s", e.signal.Name(), err.Error())
+	}
+	return &ValueExpectation[T]{t: e.t, signal: e.signal, value: v, receiver: receiver, received: received}
+}
+
+// Expectation of a single value to be received by a signal.
+type ValueExpectation[T any] struct {
+	t        testing.TB
+	signal   signals.Signal[T]
+	value    T
+	receiver signals.Receiver[T]
+	received chan struct{}
+}
+
+// Wait for the value to be received, and fail the test if it is not received within d.
+//
+// Reports whether the value was received, the receiver is disconnected afterwards.
+func (e *ValueExpectation[T]) Within(d time.Duration) bool {
+	e.t.Helper()
+	if e.receiver == nil {
+		return false
+	}
+	defer e.receiver.Disconnect()
+
+	var timer = time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-e.received:
+		return true
+	case <-timer.C:
+		e.t.Errorf("signalstest: expected signal %q to receive %v within %s", e.signal.Name(), e.value, d)
+		return false
+	}
+}
+
+// Recorder of the values received by a signal.
+type Recorder[T any] struct {
+	mu       sync.Mutex
+	values   []T
+	receiver signals.Receiver[T]
+}
+
+// Record all values received by the signal, until Stop is called.
+//
+// Panics if a receiver cannot be connected to the signal.
+func RecordAll[T any](signal signals.Signal[T]) *Recorder[T] {
+	var r = &Recorder[T]{values: make([]T, 0)}
+	var receiver, err = signal.Listen(func(s signals.Signal[T], value T) error {
+		r.mu.Lock()
+		r.values = append(r.values, value)
+		r.mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		panic("signalstest: could not record signal " + signal.Name() + ": " + err.Error())
+	}
+	r.receiver = receiver
+	return r
+}
+
+// Return a copy of the values recorded so far, in the order they were received.
+func (r *Recorder[T]) Values() []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append(make([]T, 0, len(r.values)), r.values...)
+}
+
+// Return the amount of values recorded so far.
+func (r *Recorder[T]) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.values)
+}
+
+// Report whether a value equal to v was recorded, compared with reflect.DeepEqual.
+func (r *Recorder[T]) Received(v T) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, value := range r.values {
+		if reflect.DeepEqual(value, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Stop recording, disconnecting the recorder from the signal.
+func (r *Recorder[T]) Stop() {
+	r.receiver.Disconnect()
+}
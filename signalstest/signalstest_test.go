@@ -0,0 +1,74 @@
+package signalstest_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Nigel2392/go-signals"
+	"github.com/Nigel2392/go-signals/signalstest"
+)
+
+// Test which records failures instead of failing.
+type fakeT struct {
+	testing.TB
+	errors []string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestExpectReceivesValue(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+
+	var expectation = signalstest.Expect(t, signal).ReceivesValue("hello")
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		signal.Send("other")
+		signal.Send("hello")
+	}()
+	if !expectation.Within(time.Second) {
+		t.Fatal("Expected the value to be received")
+	}
+	if signal.Count() != 0 {
+		t.Errorf("Expected the expectation to disconnect its receiver, got %d receivers", signal.Count())
+	}
+}
+
+func TestExpectMissingValue(t *testing.T) {
+	var signal = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	var fake = &fakeT{}
+
+	var expectation = signalstest.Expect[string](fake, signal).ReceivesValue("hello")
+	signal.Send("other")
+	if expectation.Within(20 * time.Millisecond) {
+		t.Fatal("Expected the missing value to be detected")
+	}
+	if len(fake.errors) != 1 {
+		t.Errorf("Expected the missing value to be reported once, got %v", fake.errors)
+	}
+}
+
+func TestRecordAll(t *testing.T) {
+	var signal = signals.New[int](strconv.Itoa(int(time.Now().UnixNano())))
+	var recorder = signalstest.RecordAll(signal)
+
+	signal.Send(1)
+	signal.Send(2)
+	recorder.Stop()
+	signal.Send(3)
+
+	if recorder.Len() != 2 {
+		t.Fatalf("Expected 2 recorded values, got %v", recorder.Values())
+	}
+	if values := recorder.Values(); values[0] != 1 || values[1] != 2 {
+		t.Errorf("Expected the values in the order they were sent, got %v", values)
+	}
+	if !recorder.Received(2) || recorder.Received(3) {
+		t.Errorf("Expected only values sent while recording to be received, got %v", recorder.Values())
+	}
+}
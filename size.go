@@ -0,0 +1,58 @@
+package signals
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// Return the size of a value in bytes.
+//
+// Byte slices and strings are measured by their length.
+//
+// Fixed-size values (numbers, booleans, arrays and structs of these)
+// are measured by the size of their type.
+//
+// Any other value is measured by the length of its JSON encoding,
+// falling back to the size of its type if it cannot be encoded.
+func DefaultSizer(v any) int {
+	switch v := v.(type) {
+	case nil:
+		return 0
+	case []byte:
+		return len(v)
+	case string:
+		return len(v)
+	}
+
+	var t = reflect.TypeOf(v)
+	if fixedSize(t) {
+		return int(t.Size())
+	}
+
+	var b, err = json.Marshal(v)
+	if err != nil {
+		return int(t.Size())
+	}
+	return len(b)
+}
+
+// Check if a type has a fixed size in memory, without any indirection.
+func fixedSize(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	case reflect.Array:
+		return fixedSize(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if !fixedSize(t.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
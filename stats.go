@@ -0,0 +1,89 @@
+package signals
+
+import (
+	"expvar"
+	"sync/atomic"
+	"time"
+)
+
+// Statistics of the values sent on a signal.
+type SignalStats struct {
+	// Number of values sent on the signal.
+	Sends uint64 `json:"sends"`
+	// Number of sends which returned an error.
+	Errors uint64 `json:"errors"`
+	// Number of receivers currently connected to the signal.
+	Receivers int `json:"receivers"`
+	// Time of the last send, or the zero time if nothing was sent yet.
+	LastSend time.Time `json:"last_send"`
+	// Average time it took to send a value to all receivers.
+	AvgLatency time.Duration `json:"avg_latency"`
+}
+
+// Counters for the statistics of a signal, updated atomically.
+type sendStats struct {
+	enabled  atomic.Bool
+	sends    atomic.Uint64
+	errors   atomic.Uint64
+	lastSend atomic.Int64
+	latency  atomic.Int64
+}
+
+// Record a send which started at start, and took the given duration.
+func (s *sendStats) record(start time.Time, duration time.Duration, failed bool) {
+	s.sends.Add(1)
+	if failed {
+		s.errors.Add(1)
+	}
+	s.lastSend.Store(start.UnixNano())
+	s.latency.Add(int64(duration))
+}
+
+// Record statistics of the values sent on the signal.
+//
+// Recording statistics times every send, this is not done by default.
+// Signals published with PublishExpvar record statistics as well.
+func WithStats[T any]() Option[T] {
+	return func(s *signal[T]) {
+		s.stats.enabled.Store(true)
+	}
+}
+
+// Start recording statistics of the values sent on the signal.
+func (s *signal[T]) enableStats() {
+	s.stats.enabled.Store(true)
+}
+
+// Return the statistics of the values sent on the signal.
+//
+// Only synchronous sends are recorded, and only while statistics are enabled
+// with WithStats or PublishExpvar. The amount of receivers is always reported.
+func (s *signal[T]) Stats() SignalStats {
+	var stats = SignalStats{
+		Sends:     s.stats.sends.Load(),
+		Errors:    s.stats.errors.Load(),
+		Receivers: s.Count(),
+	}
+	if nanos := s.stats.lastSend.Load(); nanos != 0 {
+		stats.LastSend = time.Unix(0, nanos)
+	}
+	if stats.Sends > 0 {
+		stats.AvgLatency = time.Duration(s.stats.latency.Load() / int64(stats.Sends))
+	}
+	return stats
+}
+
+// Publish the statistics of the signal as an expvar variable with the given name.
+//
+// The statistics are rendered as JSON on every request to /debug/vars.
+// Statistics are recorded from now on, if they were not enabled with WithStats.
+//
+// Like expvar.Publish, this panics if the name is already in use.
+func PublishExpvar[T any](name string, s Signal[T]) {
+	if s, ok := s.(interface{ enableStats() }); ok {
+		s.enableStats()
+	}
+	expvar.Publish(name, expvar.Func(func() any {
+		return s.Stats()
+	}))
+}
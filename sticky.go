@@ -0,0 +1,36 @@
+package signals
+
+// Retain the value if the signal is sticky.
+func (s *signal[T]) retain(value T) {
+	if !s.sticky {
+		return
+	}
+	var retained = value
+	s.retained.Store(&retained)
+}
+
+// Send the retained value to newly connected receivers.
+//
+// Errors returned by the receivers are passed to the error hook.
+func (s *signal[T]) replay(value T, receivers []Receiver[T]) {
+	for _, receiver := range receivers {
+		if err := s.call(receiver, value); err != nil {
+			s.handleError(ReceiverError{ID: receiver.ID(), Err: err})
+		}
+	}
+}
+
+// Return and clear the value retained by a sticky signal.
+//
+// Receivers connected afterwards will not receive the value,
+// until a new value is sent to the signal.
+//
+// Returns false if no value is retained, or the signal is not sticky.
+func (s *signal[T]) TakeRetained() (T, bool) {
+	var retained = s.retained.Swap(nil)
+	if retained == nil {
+		var zero T
+		return zero, false
+	}
+	return *retained, true
+}
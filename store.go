@@ -0,0 +1,127 @@
+package signals
+
+import (
+	"sync"
+)
+
+// Storage for the signals inside of a pool.
+type store[T any] interface {
+	// Load a signal by name.
+	load(name string) (Signal[T], bool)
+	// Load a signal by name, or store the given signal if it does not exist.
+	// Reports whether the signal was loaded.
+	loadOrStore(name string, value Signal[T]) (Signal[T], bool)
+	// Store a signal, replacing any existing signal with the same name.
+	store(name string, value Signal[T])
+	// Replace the signal stored under the name, if it is the old signal.
+	// Reports whether the signal was replaced.
+	compareAndSwap(name string, old, value Signal[T]) bool
+	// Delete the signal stored under the name, if it is the old signal.
+	// Reports whether the signal was deleted.
+	compareAndDelete(name string, old Signal[T]) bool
+	// Range over the signals.
+	rangeSignals(f func(value Signal[T]) bool)
+}
+
+// Storage backed by a map, guarded by a read-write mutex.
+//
+// The read lock is held while ranging over the signals.
+type mapStore[T any] struct {
+	mu sync.RWMutex
+	m  map[string]Signal[T]
+}
+
+func newMapStore[T any]() *mapStore[T] {
+	return &mapStore[T]{m: make(map[string]Signal[T])}
+}
+
+func (m *mapStore[T]) load(name string) (value Signal[T], ok bool) {
+	m.mu.RLock()
+	value, ok = m.m[name]
+	m.mu.RUnlock()
+	return
+}
+
+func (m *mapStore[T]) loadOrStore(name string, value Signal[T]) (Signal[T], bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if existing, ok := m.m[name]; ok {
+		return existing, true
+	}
+	m.m[name] = value
+	return value, false
+}
+
+func (m *mapStore[T]) store(name string, value Signal[T]) {
+	m.mu.Lock()
+	m.m[name] = value
+	m.mu.Unlock()
+}
+
+func (m *mapStore[T]) compareAndSwap(name string, old, value Signal[T]) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if current, ok := m.m[name]; !ok || current != old {
+		return false
+	}
+	m.m[name] = value
+	return true
+}
+
+func (m *mapStore[T]) compareAndDelete(name string, old Signal[T]) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if current, ok := m.m[name]; !ok || current != old {
+		return false
+	}
+	delete(m.m, name)
+	return true
+}
+
+func (m *mapStore[T]) rangeSignals(f func(value Signal[T]) bool) {
+	m.mu.RLock()
+	for _, value := range m.m {
+		if !f(value) {
+			break
+		}
+	}
+	m.mu.RUnlock()
+}
+
+// Storage backed by a sync.Map.
+//
+// No lock is held while ranging over the signals.
+type syncMapStore[T any] struct {
+	m sync.Map
+}
+
+func (m *syncMapStore[T]) load(name string) (Signal[T], bool) {
+	var value, ok = m.m.Load(name)
+	if !ok {
+		return nil, false
+	}
+	return value.(Signal[T]), true
+}
+
+func (m *syncMapStore[T]) loadOrStore(name string, value Signal[T]) (Signal[T], bool) {
+	var actual, loaded = m.m.LoadOrStore(name, value)
+	return actual.(Signal[T]), loaded
+}
+
+func (m *syncMapStore[T]) store(name string, value Signal[T]) {
+	m.m.Store(name, value)
+}
+
+func (m *syncMapStore[T]) compareAndSwap(name string, old, value Signal[T]) bool {
+	return m.m.CompareAndSwap(name, old, value)
+}
+
+func (m *syncMapStore[T]) compareAndDelete(name string, old Signal[T]) bool {
+	return m.m.CompareAndDelete(name, old)
+}
+
+func (m *syncMapStore[T]) rangeSignals(f func(value Signal[T]) bool) {
+	m.m.Range(func(_, value any) bool {
+		return f(value.(Signal[T]))
+	})
+}
@@ -0,0 +1,22 @@
+package signals
+
+// Strategy for handling errors returned by receivers.
+type ErrorStrategy int32
+
+const (
+	// Call every receiver, and return all errors they returned.
+	//
+	// This is the default strategy.
+	CollectErrors ErrorStrategy = iota
+
+	// Stop calling receivers after the first receiver returns an error,
+	// and return only that error.
+	StopOnFirstError
+)
+
+// Set how errors returned by receivers are handled.
+//
+// This applies to Send, SendDetailed, SendContext and SendWithTimeout.
+func (s *signal[T]) SetErrorStrategy(strategy ErrorStrategy) {
+	s.errorStrategy.Store(int32(strategy))
+}
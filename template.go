@@ -0,0 +1,38 @@
+package signals
+
+// Template used to construct values sent with EmitPartial.
+type template[T any] struct {
+	value T
+	merge func(tpl, partial T) T
+}
+
+// Set the template used to construct values sent with EmitPartial.
+//
+// The merge function receives the template and the partial value,
+// and returns the value which is sent to the receivers.
+//
+// Passing a nil merge function removes the template.
+func (s *signal[T]) SetTemplate(tpl T, merge func(tpl, partial T) T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if merge == nil {
+		s.template = nil
+		return
+	}
+	s.template = &template[T]{value: tpl, merge: merge}
+}
+
+// Merge the partial value into the template, and send it to all receivers.
+//
+// Will error if no template has been set.
+func (s *signal[T]) EmitPartial(partial T) error {
+	s.mu.Lock()
+	var tpl = s.template
+	s.mu.Unlock()
+
+	if tpl == nil {
+		return e("signal has no template set")
+	}
+
+	return s.Send(tpl.merge(tpl.value, partial))
+}
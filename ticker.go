@@ -0,0 +1,71 @@
+package signals
+
+import (
+	"sync"
+	"time"
+)
+
+// Send a value on the signal every interval, until stop is called.
+//
+// The value to send is computed by calling value on every tick.
+// Errors returned by the send are passed to the error hook of the signal.
+//
+// Stop does not wait for a send which is in progress, and a tick which
+// happens while stop is called may still be sent. Stop can be called more than once.
+//
+// Options can be provided to configure the ticker,
+// WithClock sets the clock used to wait for the next tick.
+//
+// Panics if the interval is not positive, like time.NewTicker.
+func Ticker[T any](s Signal[T], interval time.Duration, value func() T, opts ...RecvOption) (stop func()) {
+	if interval <= 0 {
+		panic("non-positive interval for Ticker")
+	}
+
+	var o = defaultReceiverOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	var clock = o.clock
+
+	var (
+		stopped = make(chan struct{})
+		once    sync.Once
+	)
+
+	// Register the first timer before returning, so that
+	// the first tick is one interval after the ticker was started.
+	var after = clock.After(interval)
+
+	go func() {
+		for {
+			select {
+			case <-stopped:
+				return
+			case <-after:
+			}
+
+			// Both channels might be ready, stopping takes precedence.
+			select {
+			case <-stopped:
+				return
+			default:
+			}
+
+			// The next tick is not pushed back by a slow send.
+			after = clock.After(interval)
+
+			if err := s.Send(value()); err != nil {
+				if s, ok := s.(*signal[T]); ok {
+					s.handleError(err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		once.Do(func() {
+			close(stopped)
+		})
+	}
+}
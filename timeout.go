@@ -0,0 +1,41 @@
+package signals
+
+import (
+	"fmt"
+	"time"
+)
+
+// Send a signal to all receivers, waiting at most the timeout for them to complete.
+//
+// If the receivers do not complete within the timeout, an error wrapping ErrTimeout
+// is returned. The receivers keep running in the background, their errors are
+// passed to the error hook.
+//
+// A timeout of 0 or less waits for the receivers to complete,
+// this overrides the default timeout of the signal.
+func (s *signal[T]) SendWithTimeout(timeout time.Duration, value T) error {
+	if timeout <= 0 {
+		return s.send(value).Err()
+	}
+
+	var done = make(chan error, 1)
+	go func() {
+		done <- s.send(value).Err()
+	}()
+
+	var timer = time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		go func() {
+			s.handleError(<-done)
+		}()
+		return Error{
+			Val: fmt.Sprintf("sending signal %q timed out after %s", s.name, timeout),
+			Err: ErrTimeout,
+		}
+	}
+}
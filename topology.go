@@ -0,0 +1,122 @@
+package signals
+
+import (
+	"strings"
+	"time"
+)
+
+// Serializable description of the signals declared in a pool.
+//
+// Only the names, metadata and serializable options of the signals are included,
+// receivers and options like hooks and sizers are not.
+type Topology struct {
+	Signals []SignalTopology `json:"signals"`
+}
+
+// Serializable description of a single signal.
+type SignalTopology struct {
+	Name    string            `json:"name"`
+	Meta    map[string]string `json:"meta,omitempty"`
+	Options TopologyOptions   `json:"options"`
+}
+
+// Serializable options of a signal, see the options of the same name.
+type TopologyOptions struct {
+	MaxValueSize     int           `json:"max_value_size,omitempty"`
+	RequireReceivers bool          `json:"require_receivers,omitempty"`
+	DefaultTimeout   time.Duration `json:"default_timeout,omitempty"`
+	ShuffleReceivers bool          `json:"shuffle_receivers,omitempty"`
+	TypeLock         bool          `json:"type_lock,omitempty"`
+	Recover          bool          `json:"recover,omitempty"`
+	StopOnError      bool          `json:"stop_on_error,omitempty"`
+	Sticky           bool          `json:"sticky,omitempty"`
+	RejectNil        bool          `json:"reject_nil,omitempty"`
+}
+
+// Export the names, metadata and options of the signals inside of the pool.
+//
+// For a namespace, the names are exported without the namespace's prefix.
+func (m *Pool[T]) ExportTopology() Topology {
+	var topology = Topology{Signals: make([]SignalTopology, 0)}
+	for _, value := range m.snapshot() {
+		var s, ok = value.(*signal[T])
+		if !ok {
+			continue
+		}
+
+		s.mu.RLock()
+		var t = SignalTopology{
+			Name: strings.TrimPrefix(s.name, m.prefix),
+			Options: TopologyOptions{
+				MaxValueSize:     s.maxValueSize,
+				RequireReceivers: s.requireReceivers,
+				DefaultTimeout:   s.defaultTimeout,
+				ShuffleReceivers: s.rng != nil,
+				TypeLock:         s.typeLock,
+				Recover:          s.recoverPanics.Load(),
+				StopOnError:      ErrorStrategy(s.errorStrategy.Load()) == StopOnFirstError,
+				Sticky:           s.sticky,
+				RejectNil:        s.rejectNil,
+			},
+		}
+		if len(s.meta) > 0 {
+			t.Meta = make(map[string]string, len(s.meta))
+			for k, v := range s.meta {
+				t.Meta[k] = v
+			}
+		}
+		s.mu.RUnlock()
+
+		topology.Signals = append(topology.Signals, t)
+	}
+	return topology
+}
+
+// Declare the signals described by the topology inside of the pool.
+//
+// Signals which do not exist yet are declared with the options of the topology,
+// the options of the pool are applied before those of the topology.
+//
+// Signals which already exist are not replaced, references to them stay valid.
+// They keep their options, receivers and state, only the metadata of the topology is set on them.
+func (m *Pool[T]) ImportTopology(topology Topology) {
+	for _, t := range topology.Signals {
+		var signal = m.Declare(t.Name, topologyOptions[T](t.Options)...)
+		for k, v := range t.Meta {
+			signal.SetMeta(k, v)
+		}
+	}
+}
+
+// Return the options which configure a signal like the topology options.
+func topologyOptions[T any](o TopologyOptions) []Option[T] {
+	var opts = make([]Option[T], 0)
+	if o.MaxValueSize > 0 {
+		opts = append(opts, WithMaxValueSize[T](o.MaxValueSize))
+	}
+	if o.RequireReceivers {
+		opts = append(opts, RequireReceivers[T]())
+	}
+	if o.DefaultTimeout > 0 {
+		opts = append(opts, WithDefaultTimeout[T](o.DefaultTimeout))
+	}
+	if o.ShuffleReceivers {
+		opts = append(opts, ShuffleReceivers[T]())
+	}
+	if o.TypeLock {
+		opts = append(opts, WithTypeLock[T]())
+	}
+	if o.Recover {
+		opts = append(opts, WithRecover[T]())
+	}
+	if o.StopOnError {
+		opts = append(opts, StopOnError[T]())
+	}
+	if o.Sticky {
+		opts = append(opts, Sticky[T]())
+	}
+	if o.RejectNil {
+		opts = append(opts, RejectNil[T]())
+	}
+	return opts
+}
@@ -0,0 +1,70 @@
+package signals
+
+import (
+	"sort"
+	"sync"
+)
+
+// Track signals created with New.
+//
+// When enabled, every signal created with New is registered,
+// and can be retrieved with AllSignals. This is useful for detecting
+// signals which outlive their scope in tests.
+//
+// Tracked signals must be released with Untrack, otherwise the registry keeps them alive.
+//
+// Signals created by a pool are never tracked, the pool already keeps track of them.
+//
+// This should be set before any signals are created.
+var TrackSignals bool
+
+// Signal which was created while tracking was enabled.
+type TrackedSignal interface {
+	// Return the name of the signal.
+	Name() string
+	// Clear all receivers for the signal.
+	Clear()
+}
+
+var tracked = struct {
+	mu      sync.Mutex
+	signals map[TrackedSignal]struct{}
+}{
+	signals: make(map[TrackedSignal]struct{}),
+}
+
+// Register a signal if tracking is enabled.
+func track(s TrackedSignal) {
+	if !TrackSignals {
+		return
+	}
+	tracked.mu.Lock()
+	tracked.signals[s] = struct{}{}
+	tracked.mu.Unlock()
+}
+
+// Release a signal from the registry of tracked signals.
+//
+// This should be called once the signal is no longer used.
+func Untrack(s TrackedSignal) {
+	tracked.mu.Lock()
+	delete(tracked.signals, s)
+	tracked.mu.Unlock()
+}
+
+// Return all tracked signals, sorted by name.
+//
+// Only signals created with New while TrackSignals was enabled are returned.
+func AllSignals() []TrackedSignal {
+	tracked.mu.Lock()
+	var signals = make([]TrackedSignal, 0, len(tracked.signals))
+	for s := range tracked.signals {
+		signals = append(signals, s)
+	}
+	tracked.mu.Unlock()
+
+	sort.Slice(signals, func(i, j int) bool {
+		return signals[i].Name() < signals[j].Name()
+	})
+	return signals
+}
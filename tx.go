@@ -0,0 +1,112 @@
+package signals
+
+import (
+	"context"
+	"fmt"
+)
+
+// Receivers which take part in a two-phase commit with SendTx.
+type TxReceiver[T any] interface {
+	Receiver[T]
+
+	// Prepare to receive the value, without making the changes permanent.
+	Prepare(Signal[T], T) error
+	// Make the changes of the prepared value permanent.
+	Commit() error
+	// Undo the changes of the prepared value.
+	Rollback() error
+}
+
+// Return the transactional receiver which receives the value, unwrapping receivers which wrap one.
+//
+// Returns false if the receiver is not transactional, or if its filter skips the value.
+func txReceiver[T any](receiver Receiver[T], value T) (TxReceiver[T], bool) {
+	for {
+		switch r := receiver.(type) {
+		case TxReceiver[T]:
+			return r, true
+		case *filterReceiver[T]:
+			if !r.filter(value) {
+				return nil, false
+			}
+		case *errMapReceiver[T]:
+			if tx, ok := txReceiver(r.Receiver, value); ok {
+				return &errMapTx[T]{TxReceiver: tx, mapErr: r.mapErr}, true
+			}
+			return nil, false
+		}
+
+		var w, ok = receiver.(unwrapper[T])
+		if !ok {
+			return nil, false
+		}
+		receiver = w.unwrap()
+	}
+}
+
+// Send a signal to all receivers, as a two-phase commit.
+//
+// Prepare is called on every receiver implementing TxReceiver. If any of them fail,
+// Rollback is called on the receivers which were prepared successfully.
+// Otherwise, Commit is called on all of them.
+//
+// Receivers which do not implement TxReceiver are only called
+// once all transactional receivers have been committed.
+// Receivers wrapping a TxReceiver, e.g. receivers connected with
+// ConnectFilter, take part in the commit like the receiver they wrap.
+//
+// Returns the errors of the receivers which failed to prepare, roll back or commit.
+func (s *signal[T]) SendTx(value T) error {
+	value, ok, err := s.prepare(value)
+	if !ok {
+		return err
+	}
+
+	receivers, err := s.snapshot()
+	if err != nil {
+		return err
+	}
+
+	if len(receivers) == 0 {
+		return s.noReceivers()
+	}
+
+	var (
+		prepared = make([]TxReceiver[T], 0, len(receivers))
+		others   = make([]Receiver[T], 0)
+		errs     = make([]error, 0)
+	)
+	for _, receiver := range receivers {
+		var tx, ok = txReceiver(receiver, value)
+		if !ok {
+			others = append(others, receiver)
+			continue
+		}
+		if err := tx.Prepare(s, value); err != nil {
+			errs = append(errs, ReceiverError{ID: tx.ID(), Err: err})
+			continue
+		}
+		prepared = append(prepared, tx)
+	}
+
+	if len(errs) > 0 {
+		var failed = len(errs)
+		for _, tx := range prepared {
+			if err := tx.Rollback(); err != nil {
+				errs = append(errs, ReceiverError{ID: tx.ID(), Err: err})
+			}
+		}
+		return e(fmt.Sprintf("error preparing signal for %d receivers, rolled back %d receivers", failed, len(prepared)), errs...)
+	}
+
+	for _, tx := range prepared {
+		if err := tx.Commit(); err != nil {
+			errs = append(errs, ReceiverError{ID: tx.ID(), Err: err})
+		}
+	}
+	if len(errs) > 0 {
+		return e(fmt.Sprintf("error committing signal for %d receivers", len(errs)), errs...)
+	}
+
+	return s.dispatch(context.Background(), s.generation.Load(), others, value, sendHooks[T]{}).Err()
+}
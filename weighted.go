@@ -0,0 +1,99 @@
+package signals
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+)
+
+// Receiver with a weight, used by SendWeighted to select a receiver.
+type weightedReceiver[T any] struct {
+	Receiver[T]
+	weight int
+}
+
+// Return the weight of the receiver.
+func (r *weightedReceiver[T]) Weight() int {
+	return r.weight
+}
+
+// Receives the context, signal and value from the signal.
+//
+// The context is passed on if the wrapped receiver implements ContextReceiver.
+func (r *weightedReceiver[T]) ReceiveContext(ctx context.Context, s Signal[T], value T) error {
+	return receiveContext(ctx, r.Receiver, s, value)
+}
+
+func (r *weightedReceiver[T]) unwrap() Receiver[T] {
+	return r.Receiver
+}
+
+func (r *weightedReceiver[T]) rewrap(inner Receiver[T]) Receiver[T] {
+	return &weightedReceiver[T]{Receiver: inner, weight: r.weight}
+}
+
+// Return the weight of the receiver, receivers without a weight have a weight of 1.
+//
+// The weight of a receiver which is wrapped, such as by ConnectPriority, is kept.
+func receiverWeight[T any](receiver Receiver[T]) int {
+	if weighted, ok := unwrapAs[Weighted](receiver); ok {
+		return weighted.Weight()
+	}
+	return 1
+}
+
+// Connect a receiver with a weight, used by SendWeighted to select a receiver.
+//
+// The weight must be greater than zero.
+func (s *signal[T]) ConnectWeighted(weight int, receiver Receiver[T]) error {
+	if receiver == nil {
+		return wrap(ErrNilReceiver)
+	}
+	if weight <= 0 {
+		return e(fmt.Sprintf("weight must be greater than zero, got %d", weight))
+	}
+	return s.Connect(&weightedReceiver[T]{Receiver: receiver, weight: weight})
+}
+
+// Send a signal to a single receiver, selected randomly by weight.
+//
+// The chance of a receiver being selected is proportional to its weight.
+// Receivers connected without ConnectWeighted have a weight of 1,
+// unless they implement the Weighted interface.
+//
+// Returns the error of the selected receiver.
+func (s *signal[T]) SendWeighted(value T) error {
+	value, ok, err := s.prepare(value)
+	if !ok {
+		return err
+	}
+
+	var generation = s.generation.Load()
+	receivers, err := s.snapshot()
+	if err != nil {
+		return err
+	}
+
+	var total int
+	for _, receiver := range receivers {
+		if weight := receiverWeight(receiver); weight > 0 {
+			total += weight
+		}
+	}
+	if total == 0 {
+		return s.noReceivers()
+	}
+
+	var pick = rand.Intn(total)
+	for _, receiver := range receivers {
+		var weight = receiverWeight(receiver)
+		if weight <= 0 {
+			continue
+		}
+		if pick < weight {
+			return s.dispatch(context.Background(), generation, []Receiver[T]{receiver}, value, sendHooks[T]{}).Err()
+		}
+		pick -= weight
+	}
+	return nil
+}
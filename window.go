@@ -0,0 +1,185 @@
+package signals
+
+import (
+	"sync"
+	"time"
+)
+
+// Number constraint for receivers which compute statistics.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Statistics of the values received within a window.
+type WindowStats struct {
+	// The start and end of the window.
+	Start, End time.Time
+	// The amount of values received.
+	Count int
+	// The smallest, largest and average value received,
+	// all zero if no values were received.
+	Min, Max, Avg float64
+}
+
+// Value received by a window statistics receiver, and the time it was received at.
+type windowValue struct {
+	at    time.Time
+	value float64
+}
+
+// Receiver which emits statistics over a sliding window of time.
+type windowStatsReceiver[T Number] struct {
+	*receiver[T]
+	window  time.Duration
+	emit    func(WindowStats) error
+	mu      sync.Mutex
+	values  []windowValue // Values received within the window, oldest first.
+	halt    chan struct{} // Closed to stop the current ticks, nil while not ticking.
+	stopped chan struct{}
+	once    sync.Once
+}
+
+// Initialize a new receiver which emits statistics over a sliding window of time.
+//
+// The values received within the window are kept, on every tick the statistics
+// of the values received within the window before the tick are passed to emit.
+// Values which have fallen out of the window are dropped.
+//
+// The first value received starts the ticks, after which statistics are emitted
+// on every tick, also if no values were received within the window.
+// The ticks are stopped by disconnecting the receiver, and are started again
+// by the first value received after it is connected again.
+// Closing the receiver, which Shutdown does, stops the ticks for good;
+// values received afterwards are ignored.
+//
+// Errors returned by emit are passed to the error hook of the signal,
+// as the values have already been sent by the time emit is called.
+//
+// Options can be provided to configure the receiver,
+// WithClock sets the clock used to time the window and
+// WithEmitInterval sets how often statistics are emitted, by default once per window.
+func NewWindowStatsRecv[T Number](window time.Duration, emit func(WindowStats) error, opts ...RecvOption) Receiver[T] {
+	var r = &windowStatsReceiver[T]{
+		window:  window,
+		emit:    emit,
+		stopped: make(chan struct{}),
+	}
+	// Prepend the default interval, so that the receiver does not share its options.
+	r.receiver = NewRecv(r.receive, append([]RecvOption{WithEmitInterval(window)}, opts...)...)
+	if r.opts.interval <= 0 {
+		r.opts.interval = window
+	}
+	return r
+}
+
+// Stop the ticks of the receiver.
+func (r *windowStatsReceiver[T]) Close() error {
+	r.once.Do(func() {
+		close(r.stopped)
+	})
+	return nil
+}
+
+// Sets the signal on the receiver instance for later use.
+//
+// The ticks are stopped once the receiver is disconnected.
+func (r *windowStatsReceiver[T]) Signal(signal ...Signal[T]) Signal[T] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(signal) > 0 && signal[0] == nil && r.halt != nil {
+		close(r.halt)
+		r.halt = nil
+	}
+	return r.receiver.Signal(signal...)
+}
+
+func (r *windowStatsReceiver[T]) receive(s Signal[T], value T) error {
+	select {
+	case <-r.stopped:
+		return nil
+	default:
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.values = append(r.values, windowValue{at: r.opts.clock.Now(), value: float64(value)})
+	// A send which is in progress can still call a receiver which was disconnected.
+	if r.halt == nil && r.receiver.Signal() != nil {
+		r.halt = make(chan struct{})
+
+		// Register the timer before returning, so that
+		// the ticks start at the time the value was received.
+		go r.tick(s, r.opts.clock.After(r.opts.interval), r.halt)
+	}
+	return nil
+}
+
+// Emit the statistics of the window on every tick,
+// until the receiver is closed or the ticks are halted.
+func (r *windowStatsReceiver[T]) tick(s Signal[T], after <-chan time.Time, halt <-chan struct{}) {
+	for {
+		var now time.Time
+		select {
+		case <-r.stopped:
+			return
+		case <-halt:
+			return
+		case now = <-after:
+		}
+
+		// The channels might be ready at the same time, stopping takes precedence.
+		select {
+		case <-r.stopped:
+			return
+		case <-halt:
+			return
+		default:
+		}
+
+		// The next tick is not pushed back by a slow emit.
+		after = r.opts.clock.After(r.opts.interval)
+
+		if err := r.emit(r.stats(now)); err != nil {
+			if s, ok := s.(*signal[T]); ok {
+				s.handleError(err)
+			}
+		}
+	}
+}
+
+// Return the statistics of the values received within the window ending at end,
+// dropping the values which were received before the window started.
+func (r *windowStatsReceiver[T]) stats(end time.Time) WindowStats {
+	var stats = WindowStats{Start: end.Add(-r.window), End: end}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var expired = 0
+	for expired < len(r.values) && r.values[expired].at.Before(stats.Start) {
+		expired++
+	}
+	r.values = append(r.values[:0], r.values[expired:]...)
+
+	var sum float64
+	for _, v := range r.values {
+		// Values received at the end of the window belong to the next one.
+		if !v.at.Before(end) {
+			break
+		}
+		if stats.Count == 0 || v.value < stats.Min {
+			stats.Min = v.value
+		}
+		if stats.Count == 0 || v.value > stats.Max {
+			stats.Max = v.value
+		}
+		stats.Count++
+		sum += v.value
+	}
+	if stats.Count > 0 {
+		stats.Avg = sum / float64(stats.Count)
+	}
+	return stats
+}
@@ -0,0 +1,200 @@
+package signals
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Maximum size of the payload of a single frame in the wire format.
+//
+// NewWireRecv refuses to write larger payloads, and WireDecode stops reading
+// when the peer announces one, instead of allocating the announced length.
+//
+// If zero or negative, payloads are only limited by the 4 byte length header.
+var MaxWireFrameSize = 16 << 20
+
+// Codec used to encode and decode values for the wire format.
+type codec struct {
+	encode func(any) ([]byte, error)
+	decode func([]byte) (any, error)
+}
+
+var codecs = struct {
+	mu sync.RWMutex
+	m  map[string]codec
+}{
+	m: make(map[string]codec),
+}
+
+// Register a codec for the wire format under the given tag.
+//
+// The tag is written in front of every encoded message,
+// so that the receiving end knows which codec to decode it with.
+//
+// Tags can be at most 255 bytes long, registering a tag twice replaces the codec.
+func RegisterCodec[T any](tag string, encode func(T) ([]byte, error), decode func([]byte) (T, error)) {
+	if len(tag) == 0 || len(tag) > 255 {
+		panic(fmt.Sprintf("codec tag must be between 1 and 255 bytes, got %d", len(tag)))
+	}
+
+	codecs.mu.Lock()
+	defer codecs.mu.Unlock()
+	codecs.m[tag] = codec{
+		encode: func(v any) ([]byte, error) {
+			var value, ok = v.(T)
+			if !ok {
+				return nil, Error{
+					Val: fmt.Sprintf("codec %q encodes %T, got %T", tag, value, v),
+					Err: ErrTypeMismatch,
+				}
+			}
+			return encode(value)
+		},
+		decode: func(b []byte) (any, error) {
+			return decode(b)
+		},
+	}
+}
+
+// Return the codec registered under the tag.
+func getCodec(tag string) (codec, error) {
+	codecs.mu.RLock()
+	defer codecs.mu.RUnlock()
+	var c, ok = codecs.m[tag]
+	if !ok {
+		return c, e(fmt.Sprintf("no codec registered for tag %q", tag))
+	}
+	return c, nil
+}
+
+// Initialize a new receiver which writes values to w in the wire format.
+//
+// Each value is encoded with the codec registered under the tag, and written as a single frame:
+//
+//	[tag length: 1 byte][tag][payload length: 4 bytes, big endian][payload]
+//
+// Frames can be read back with WireDecode.
+func NewWireRecv[T any](w io.Writer, tag string) Receiver[T] {
+	var mu sync.Mutex
+	return NewRecv(func(s Signal[T], value T) error {
+		var c, err = getCodec(tag)
+		if err != nil {
+			return err
+		}
+
+		payload, err := c.encode(value)
+		if err != nil {
+			return err
+		}
+		if err = checkFrameSize(uint64(len(payload))); err != nil {
+			return err
+		}
+
+		var frame = make([]byte, 0, 1+len(tag)+4+len(payload))
+		frame = append(frame, byte(len(tag)))
+		frame = append(frame, tag...)
+		frame = binary.BigEndian.AppendUint32(frame, uint32(len(payload)))
+		frame = append(frame, payload...)
+
+		// Frames must not be interleaved when sent concurrently.
+		mu.Lock()
+		defer mu.Unlock()
+		_, err = w.Write(frame)
+		return err
+	})
+}
+
+// Return ErrFrameTooLarge if a payload of the given size exceeds MaxWireFrameSize.
+func checkFrameSize(size uint64) error {
+	if size > 1<<32-1 || MaxWireFrameSize > 0 && size > uint64(MaxWireFrameSize) {
+		return wrap(ErrFrameTooLarge)
+	}
+	return nil
+}
+
+// Read frames in the wire format from r, and send the decoded values on the signal.
+//
+// Reading stops once r returns io.EOF, or when a frame cannot be read or decoded.
+// Frames with a payload larger than MaxWireFrameSize stop reading with ErrFrameTooLarge.
+//
+// Errors returned by sending on the signal do not stop reading,
+// they are returned together once reading has stopped.
+func WireDecode[T any](r io.Reader, s Signal[T]) error {
+	var (
+		reader  = bufio.NewReader(r)
+		errs    = make([]error, 0)
+		header  [4]byte
+		payload []byte
+	)
+
+	// Return the error which stopped reading,
+	// together with the errors returned by sending until then.
+	var stop = func(err error) error {
+		if len(errs) == 0 {
+			return err
+		}
+		return e(
+			fmt.Sprintf("%s (after %d failed sends)", err.Error(), len(errs)),
+			append([]error{err}, errs...)...,
+		)
+	}
+
+	for {
+		var tagLen, err = reader.ReadByte()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return stop(err)
+		}
+
+		var tag = make([]byte, tagLen)
+		if _, err = io.ReadFull(reader, tag); err != nil {
+			return stop(err)
+		}
+		if _, err = io.ReadFull(reader, header[:]); err != nil {
+			return stop(err)
+		}
+
+		var size = binary.BigEndian.Uint32(header[:])
+		if err = checkFrameSize(uint64(size)); err != nil {
+			return stop(err)
+		}
+
+		payload = make([]byte, size)
+		if _, err = io.ReadFull(reader, payload); err != nil {
+			return stop(err)
+		}
+
+		c, err := getCodec(string(tag))
+		if err != nil {
+			return stop(err)
+		}
+
+		decoded, err := c.decode(payload)
+		if err != nil {
+			return stop(err)
+		}
+
+		var value, ok = decoded.(T)
+		if !ok {
+			return stop(Error{
+				Val: fmt.Sprintf("codec %q decoded %T, expected %T", tag, decoded, value),
+				Err: ErrTypeMismatch,
+			})
+		}
+
+		if err = s.Send(value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return e(fmt.Sprintf("error sending %d decoded values", len(errs)), errs...)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package signals_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Nigel2392/go-signals"
+)
+
+type point struct {
+	X, Y int32
+}
+
+func init() {
+	signals.RegisterCodec("point", func(p point) ([]byte, error) {
+		var b = make([]byte, 8)
+		binary.BigEndian.PutUint32(b[0:], uint32(p.X))
+		binary.BigEndian.PutUint32(b[4:], uint32(p.Y))
+		return b, nil
+	}, func(b []byte) (point, error) {
+		if len(b) != 8 {
+			return point{}, errors.New("invalid point")
+		}
+		return point{
+			X: int32(binary.BigEndian.Uint32(b[0:])),
+			Y: int32(binary.BigEndian.Uint32(b[4:])),
+		}, nil
+	})
+}
+
+func TestWireRoundTrip(t *testing.T) {
+	var source = signals.New[point](strconv.Itoa(int(time.Now().UnixNano())))
+	var destination = signals.New[point](strconv.Itoa(int(time.Now().UnixNano())))
+
+	var pr, pw = io.Pipe()
+	source.Connect(signals.NewWireRecv[point](pw, "point"))
+
+	var received = make([]point, 0)
+	destination.Listen(func(signal signals.Signal[point], value point) error {
+		received = append(received, value)
+		return nil
+	})
+
+	var sent = []point{{1, 2}, {-3, 4}, {5, -6}}
+	go func() {
+		for _, p := range sent {
+			if err := source.Send(p); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+		pw.Close()
+	}()
+
+	if err := signals.WireDecode(pr, destination); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+
+	if len(received) != len(sent) {
+		t.Fatalf("Expected %d values, got %d", len(sent), len(received))
+	}
+	for i := range sent {
+		if received[i] != sent[i] {
+			t.Errorf("Expected %v at index %d, got %v", sent[i], i, received[i])
+		}
+	}
+}
+
+func TestWireFrameSize(t *testing.T) {
+	signals.MaxWireFrameSize = 4
+	defer func() { signals.MaxWireFrameSize = 16 << 20 }()
+
+	var buf bytes.Buffer
+	var source = signals.New[point](strconv.Itoa(int(time.Now().UnixNano())))
+	source.Connect(signals.NewWireRecv[point](&buf, "point"))
+	if err := source.Send(point{1, 2}); !errors.Is(err, signals.ErrFrameTooLarge) {
+		t.Fatalf("Expected ErrFrameTooLarge when encoding, got %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("Expected nothing to be written, got %d bytes", buf.Len())
+	}
+
+	// A frame announcing a payload of 4 GiB must be rejected before it is allocated.
+	var frame = []byte{5, 'p', 'o', 'i', 'n', 't', 0xff, 0xff, 0xff, 0xff}
+	var destination = signals.New[point](strconv.Itoa(int(time.Now().UnixNano())))
+	destination.Listen(func(signal signals.Signal[point], value point) error {
+		return nil
+	})
+	if err := signals.WireDecode(bytes.NewReader(frame), destination); !errors.Is(err, signals.ErrFrameTooLarge) {
+		t.Fatalf("Expected ErrFrameTooLarge when decoding, got %v", err)
+	}
+}
+
+func TestWireDecodeErrors(t *testing.T) {
+	var buf bytes.Buffer
+	var source = signals.New[point](strconv.Itoa(int(time.Now().UnixNano())))
+	source.Connect(signals.NewWireRecv[point](&buf, "point"))
+	for i := 0; i < 2; i++ {
+		if err := source.Send(point{int32(i), 0}); err != nil {
+			t.Fatalf("Expected no errors, got %s", err.Error())
+		}
+	}
+
+	// Truncate the last frame, so that reading stops after both sends failed.
+	var data = append(buf.Bytes(), 5, 'p', 'o')
+
+	var errFailed = errors.New("failed")
+	var destination = signals.New[point](strconv.Itoa(int(time.Now().UnixNano())))
+	destination.Listen(func(signal signals.Signal[point], value point) error {
+		return errFailed
+	})
+
+	var err = signals.WireDecode(bytes.NewReader(data), destination)
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("Expected io.ErrUnexpectedEOF, got %v", err)
+	}
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("Expected the errors of the failed sends to be kept, got %v", err)
+	}
+
+	var signalErr, ok = signals.SignalError(err)
+	if !ok || signalErr.Len() != 3 {
+		t.Fatalf("Expected the read error and 2 send errors, got %v", err)
+	}
+}
+
+func TestWireTypeMismatch(t *testing.T) {
+	var buf bytes.Buffer
+	var source = signals.New[any](strconv.Itoa(int(time.Now().UnixNano())))
+	source.Connect(signals.NewWireRecv[any](&buf, "point"))
+	if err := source.Send(42); !errors.Is(err, signals.ErrTypeMismatch) {
+		t.Fatalf("Expected ErrTypeMismatch, got %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("Expected nothing to be written, got %d bytes", buf.Len())
+	}
+
+	// Decoding a frame into a signal of a different type also returns ErrTypeMismatch.
+	var sent = signals.New[point](strconv.Itoa(int(time.Now().UnixNano())))
+	sent.Connect(signals.NewWireRecv[point](&buf, "point"))
+	if err := sent.Send(point{1, 2}); err != nil {
+		t.Fatalf("Expected no errors, got %s", err.Error())
+	}
+	var destination = signals.New[string](strconv.Itoa(int(time.Now().UnixNano())))
+	if err := signals.WireDecode(&buf, destination); !errors.Is(err, signals.ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch decoding into a signal of another type, got %v", err)
+	}
+}